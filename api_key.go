@@ -59,6 +59,7 @@ func (service *APIKeyService) List(ctx context.Context, spaceID string) *Collect
 	path := fmt.Sprintf("/spaces/%s%s/api_keys", spaceID, getEnvPath(service.c))
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "APIKeys", "List", spaceID, "")
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return &Collection[APIKey]{}
@@ -76,6 +77,7 @@ func (service *APIKeyService) Get(ctx context.Context, spaceID, apiKeyID string)
 	path := fmt.Sprintf("/spaces/%s%s/api_keys/%s", spaceID, getEnvPath(service.c), apiKeyID)
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "APIKeys", "Get", spaceID, apiKeyID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return nil, err
@@ -107,6 +109,7 @@ func (service *APIKeyService) Upsert(ctx context.Context, spaceID string, apiKey
 		method = http.MethodPost
 	}
 
+	ctx = service.c.operation(ctx, "APIKeys", "Upsert", spaceID, sysID(apiKey.Sys))
 	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
 	if err != nil {
 		return err
@@ -122,6 +125,7 @@ func (service *APIKeyService) Delete(ctx context.Context, spaceID string, apiKey
 	path := fmt.Sprintf("/spaces/%s%s/api_keys/%s", spaceID, getEnvPath(service.c), apiKey.Sys.ID)
 	method := http.MethodDelete
 
+	ctx = service.c.operation(ctx, "APIKeys", "Delete", spaceID, apiKey.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -76,6 +76,7 @@ func (service *AssetsService) List(ctx context.Context, spaceID string) *Collect
 	path := fmt.Sprintf("/spaces/%s%s/assets", spaceID, getEnvPath(service.c))
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Assets", "List", spaceID, "")
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return &Collection[Asset]{}
@@ -98,6 +99,7 @@ func (service *AssetsService) Get(ctx context.Context, spaceID, assetID string,
 
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Assets", "Get", spaceID, assetID)
 	req, err := service.c.newRequest(ctx, method, path, query, nil, nil)
 	if err != nil {
 		return nil, err
@@ -167,6 +169,7 @@ func (service *AssetsService) Upsert(ctx context.Context, spaceID string, asset
 		method = http.MethodPost
 	}
 
+	ctx = service.c.operation(ctx, "Assets", "Upsert", spaceID, sysID(asset.Sys))
 	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
 	if err != nil {
 		return err
@@ -182,6 +185,7 @@ func (service *AssetsService) Delete(ctx context.Context, spaceID string, asset
 	path := fmt.Sprintf("/spaces/%s%s/assets/%s", spaceID, getEnvPath(service.c), asset.Sys.ID)
 	method := http.MethodDelete
 
+	ctx = service.c.operation(ctx, "Assets", "Delete", spaceID, asset.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -195,9 +199,9 @@ func (service *AssetsService) Delete(ctx context.Context, spaceID string, asset
 
 // Process the asset
 func (service *AssetsService) Process(ctx context.Context, spaceID string, asset *Asset) error {
-	var locale string
-	for k := range asset.Fields.Title {
-		locale = k
+	ctx = service.c.operation(ctx, "Assets", "Process", spaceID, asset.Sys.ID)
+
+	for locale := range asset.Fields.File {
 		path := fmt.Sprintf("/spaces/%s%s/assets/%s/files/%s/process", spaceID, getEnvPath(service.c), asset.Sys.ID, locale)
 		method := http.MethodPut
 
@@ -221,6 +225,7 @@ func (service *AssetsService) Publish(ctx context.Context, spaceID string, asset
 	path := fmt.Sprintf("/spaces/%s%s/assets/%s/published", spaceID, getEnvPath(service.c), asset.Sys.ID)
 	method := http.MethodPut
 
+	ctx = service.c.operation(ctx, "Assets", "Publish", spaceID, asset.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -237,6 +242,7 @@ func (service *AssetsService) Unpublish(ctx context.Context, spaceID string, ass
 	path := fmt.Sprintf("/spaces/%s%s/assets/%s/published", spaceID, getEnvPath(service.c), asset.Sys.ID)
 	method := http.MethodDelete
 
+	ctx = service.c.operation(ctx, "Assets", "Unpublish", spaceID, asset.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -0,0 +1,40 @@
+package contentful
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetProcess(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"2"}, r.Header["X-Contentful-Version"])
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	// every file is processed, also of locales without title
+	asset := &Asset{
+		Sys: &Sys{ID: "nyancat", Version: 2},
+		Fields: &FileFields{
+			Title: map[string]string{"en-US": "Nyan Cat"},
+			File: map[string]*File{
+				"en-US": {Name: "nyancat.png", UploadURL: "https://example.com/nyancat.png"},
+				"de-DE": {Name: "nyankatze.png", UploadURL: "https://example.com/nyankatze.png"},
+			},
+		},
+	}
+	require.NoError(t, cma.Assets.Process(t.Context(), spaceID, asset))
+	assert.ElementsMatch(t, []string{
+		"PUT /spaces/" + spaceID + "/assets/nyancat/files/en-US/process",
+		"PUT /spaces/" + spaceID + "/assets/nyancat/files/de-DE/process",
+	}, requests)
+}
@@ -0,0 +1,325 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/foomo/contentful"
+)
+
+type contentTypeData struct {
+	ID          string
+	Name        string
+	Type        string
+	Description string
+	Definition  string
+	Fields      []fieldData
+}
+
+type fieldData struct {
+	ID        string
+	Name      string
+	Const     string
+	Type      string
+	Localized string
+	Comment   []string
+}
+
+// Generate returns the formatted Go source for the content types
+func Generate(pkg string, contentTypes []*contentful.ContentType) ([]byte, error) {
+	var data []contentTypeData
+	for _, ct := range contentTypes {
+		ctData, err := newContentTypeData(ct)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, ctData)
+	}
+	slices.SortFunc(data, func(a, b contentTypeData) int {
+		return strings.Compare(a.Type, b.Type)
+	})
+	for i := 1; i < len(data); i++ {
+		if data[i].Type == data[i-1].Type {
+			return nil, fmt.Errorf("content types %q and %q map to the same type %s", data[i-1].ID, data[i].ID, data[i].Type)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, map[string]interface{}{
+		"Package":      pkg,
+		"ContentTypes": data,
+	}); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+func newContentTypeData(ct *contentful.ContentType) (contentTypeData, error) {
+	if ct.Sys == nil || ct.Sys.ID == "" {
+		return contentTypeData{}, fmt.Errorf("content type %q has no id", ct.Name)
+	}
+
+	name := ct.Name
+	if name == "" {
+		name = ct.Sys.ID
+	}
+
+	// the definition keeps the fields and their validations only
+	definition, err := json.Marshal(&contentful.ContentType{
+		Sys:          &contentful.Sys{ID: ct.Sys.ID, Type: "ContentType"},
+		Name:         ct.Name,
+		Description:  ct.Description,
+		DisplayField: ct.DisplayField,
+		Fields:       ct.Fields,
+	})
+	if err != nil {
+		return contentTypeData{}, err
+	}
+
+	data := contentTypeData{
+		ID:          ct.Sys.ID,
+		Name:        name,
+		Type:        goName(name),
+		Description: strings.Join(strings.Fields(ct.Description), " "),
+		Definition:  strconv.Quote(string(definition)),
+	}
+
+	seen := map[string]string{}
+	for _, field := range ct.Fields {
+		fieldName := goName(field.ID)
+		if other, ok := seen[fieldName]; ok {
+			return contentTypeData{}, fmt.Errorf("fields %q and %q of content type %q map to the same name %s", other, field.ID, ct.Sys.ID, fieldName)
+		}
+		seen[fieldName] = field.ID
+
+		fieldType := goType(field.Type, field.LinkType, field.Items)
+		data.Fields = append(data.Fields, fieldData{
+			ID:        field.ID,
+			Name:      fieldName,
+			Const:     data.Type + "Field" + fieldName,
+			Type:      fieldType,
+			Localized: localizedType(fieldType),
+			Comment:   fieldComment(field),
+		})
+	}
+
+	return data, nil
+}
+
+// goType maps a field type to the Go type of a single locale value
+func goType(fieldType, linkType string, items *contentful.FieldTypeArrayItem) string {
+	switch fieldType {
+	case contentful.FieldTypeSymbol, contentful.FieldTypeText, contentful.FieldTypeDate:
+		return "string"
+	case contentful.FieldTypeInteger:
+		return "*int64"
+	case contentful.FieldTypeNumber:
+		return "*float64"
+	case contentful.FieldTypeBoolean:
+		return "*bool"
+	case contentful.FieldTypeLocation:
+		return "*contentful.Location"
+	case contentful.FieldTypeLink:
+		return "*contentful.Link"
+	case contentful.FieldTypeArray:
+		if items == nil {
+			return "[]interface{}"
+		}
+		itemType := goType(items.Type, items.LinkType, nil)
+		if itemType == "[]interface{}" {
+			return itemType
+		}
+		return "[]" + itemType
+	default:
+		// Object, RichText and unknown types
+		return "map[string]interface{}"
+	}
+}
+
+// localizedType maps the Go type of a single locale value to a map by locale
+func localizedType(fieldType string) string {
+	switch fieldType {
+	case "*int64", "*float64", "*bool":
+		return "map[string]" + strings.TrimPrefix(fieldType, "*")
+	default:
+		return "map[string]" + fieldType
+	}
+}
+
+// fieldComment describes the field including its validation metadata
+func fieldComment(field *contentful.Field) []string {
+	var flags []string
+	if field.Required {
+		flags = append(flags, "required")
+	}
+	if field.Localized {
+		flags = append(flags, "localized")
+	}
+	if field.Disabled {
+		flags = append(flags, "disabled")
+	}
+	if field.Omitted {
+		flags = append(flags, "omitted")
+	}
+
+	description := field.Type
+	if field.LinkType != "" {
+		description += " of " + field.LinkType
+	}
+	if field.Items != nil {
+		description += " of " + field.Items.Type
+		if field.Items.LinkType != "" {
+			description += " " + field.Items.LinkType
+		}
+	}
+
+	comment := []string{fmt.Sprintf("%s is the %q field, %s", goName(field.ID), field.Name, description)}
+	if len(flags) > 0 {
+		comment[0] += ", " + strings.Join(flags, ", ")
+	}
+
+	validations := field.Validations
+	if field.Items != nil {
+		validations = append(slices.Clone(validations), field.Items.Validations...)
+	}
+	for _, validation := range validations {
+		if b, err := json.Marshal(validation); err == nil {
+			comment = append(comment, "validation: "+string(b))
+		}
+	}
+
+	return comment
+}
+
+// goName converts an id or name to an exported Go identifier
+func goName(s string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "X" + name
+	}
+
+	return name
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{
+	"unexported": func(s string) string {
+		r, size := utf8.DecodeRuneInString(s)
+		return string(unicode.ToLower(r)) + s[size:]
+	},
+}).Parse(`// Code generated by contentful-gen. DO NOT EDIT.
+
+package {{.Package}}
+{{if .ContentTypes}}
+import (
+	"encoding/json"
+
+	"github.com/foomo/contentful"
+)
+{{end}}
+{{- range .ContentTypes}}
+// {{.Type}}ContentType is the id of the {{.Name}} content type
+const {{.Type}}ContentType = {{printf "%q" .ID}}
+
+// Fields of the {{.Name}} content type
+const (
+{{- range .Fields}}
+	{{.Const}} = {{printf "%q" .ID}}
+{{- end}}
+)
+
+// {{.Type}} is an entry of the {{.Name}} content type in a single locale{{if .Description}}.
+// {{.Description}}{{end}}
+type {{.Type}} struct {
+	Sys    *contentful.Sys ` + "`json:\"sys\"`" + `
+	Fields {{.Type}}Fields ` + "`json:\"fields\"`" + `
+}
+
+// {{.Type}}Fields are the fields of the {{.Name}} content type in a single locale
+type {{.Type}}Fields struct {
+{{- range .Fields}}
+{{- range .Comment}}
+	// {{.}}
+{{- end}}
+	{{.Name}} {{.Type}} ` + "`json:\"{{.ID}},omitempty\"`" + `
+{{- end}}
+}
+
+// {{.Type}}Localized is an entry of the {{.Name}} content type with the
+// values of all locales
+type {{.Type}}Localized struct {
+	Sys    *contentful.Sys ` + "`json:\"sys\"`" + `
+	Fields {{.Type}}LocalizedFields ` + "`json:\"fields\"`" + `
+}
+
+// {{.Type}}LocalizedFields are the fields of the {{.Name}} content type by locale
+type {{.Type}}LocalizedFields struct {
+{{- range .Fields}}
+	{{.Name}} {{.Localized}} ` + "`json:\"{{.ID}},omitempty\"`" + `
+{{- end}}
+}
+
+// New{{.Type}} returns a new {{.Name}} entry
+func New{{.Type}}(id string) *{{.Type}} {
+	return &{{.Type}}{Sys: new{{.Type}}Sys(id)}
+}
+
+// New{{.Type}}Localized returns a new {{.Name}} entry for all locales
+func New{{.Type}}Localized(id string) *{{.Type}}Localized {
+	return &{{.Type}}Localized{Sys: new{{.Type}}Sys(id)}
+}
+
+// New{{.Type}}Link returns a link to the {{.Name}} entry
+func New{{.Type}}Link(id string) *contentful.Link {
+	return contentful.NewLink("Entry", id)
+}
+
+// {{.Type}}Definition returns the {{.Name}} content type the code was
+// generated from, including the field validations
+func {{.Type}}Definition() *contentful.ContentType {
+	var ct contentful.ContentType
+	if err := json.Unmarshal([]byte({{.Type | unexported}}Definition), &ct); err != nil {
+		panic(err)
+	}
+	return &ct
+}
+
+const {{.Type | unexported}}Definition = {{.Definition}}
+
+func new{{.Type}}Sys(id string) *contentful.Sys {
+	return &contentful.Sys{
+		ID:   id,
+		Type: "Entry",
+		ContentType: &contentful.ContentType{
+			Sys: &contentful.Sys{ID: {{.Type}}ContentType, Type: "Link", LinkType: "ContentType"},
+		},
+	}
+}
+{{end}}`))
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/foomo/contentful"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	contentTypes, err := readContentTypes("../../testdata/content_types.json")
+	require.NoError(t, err)
+	require.Len(t, contentTypes, 4)
+
+	src, err := Generate("models", contentTypes)
+	require.NoError(t, err)
+
+	// the generated code type checks against the sdk
+	scope := typeCheck(t, src).Scope()
+	for _, name := range []string{
+		"Cat", "CatFields", "CatLocalized", "CatLocalizedFields",
+		"NewCat", "NewCatLocalized", "NewCatLink", "CatDefinition",
+		"CatContentType", "CatFieldBestFriend", "City", "Dog", "Human",
+	} {
+		assert.NotNil(t, scope.Lookup(name), name)
+	}
+
+	fields := scope.Lookup("CatFields").Type().Underlying().(*types.Struct)
+	fieldTypes := map[string]string{}
+	for i := range fields.NumFields() {
+		fieldTypes[fields.Field(i).Name()] = fields.Field(i).Type().String()
+	}
+	assert.Equal(t, map[string]string{
+		"Name":       "string",
+		"Likes":      "[]string",
+		"Color":      "string",
+		"BestFriend": "*github.com/foomo/contentful.Link",
+		"Birthday":   "string",
+		"Lifes":      "*int64",
+		"Lives":      "*int64",
+		"Image":      "*github.com/foomo/contentful.Link",
+	}, fieldTypes)
+
+	localized := scope.Lookup("CityLocalizedFields").Type().Underlying().(*types.Struct)
+	assert.Equal(t, "map[string]*github.com/foomo/contentful.Location", localized.Field(1).Type().String())
+}
+
+func TestGenerateValidations(t *testing.T) {
+	dir := t.TempDir()
+	data, err := os.ReadFile("../../testdata/content_type_with_validations.json")
+	require.NoError(t, err)
+	path := filepath.Join(dir, "export.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"contentTypes":[`+string(data)+`]}`), 0o600))
+
+	contentTypes, err := readContentTypes(path)
+	require.NoError(t, err)
+	require.Len(t, contentTypes, 1)
+
+	src, err := Generate("models", contentTypes)
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(src), `// validation: {"size":{"min":10,"max":20},"message":"text-short range error message"}`))
+	assert.True(t, strings.Contains(string(src), `// validation: {"linkContentType":["sFzTZbSuM8coEwygeUYes","6XwpTaSiiI2Ak2Ww0oi6qa","2PqfXUJwE8qSYKuM0U6w8M"]}`))
+}
+
+func TestGoName(t *testing.T) {
+	for in, out := range map[string]string{
+		"bestFriend":    "BestFriend",
+		"Blog Post":     "BlogPost",
+		"seo-meta_data": "SeoMetaData",
+		"1t9IbcfdCk6m":  "X1t9IbcfdCk6m",
+	} {
+		assert.Equal(t, out, goName(in))
+	}
+}
+
+func TestGenerateConflict(t *testing.T) {
+	_, err := Generate("models", []*contentful.ContentType{
+		{Sys: &contentful.Sys{ID: "a"}, Name: "Blog post"},
+		{Sys: &contentful.Sys{ID: "b"}, Name: "blog-post"},
+	})
+	require.Error(t, err)
+}
+
+func TestGenerateUnicode(t *testing.T) {
+	src, err := Generate("models", []*contentful.ContentType{
+		{Sys: &contentful.Sys{ID: "about"}, Name: "Über uns"},
+	})
+	require.NoError(t, err)
+
+	scope := typeCheck(t, src).Scope()
+	assert.NotNil(t, scope.Lookup("ÜberUns"))
+	assert.NotNil(t, scope.Lookup("überUnsDefinition"))
+}
+
+func TestGenerateEmpty(t *testing.T) {
+	src, err := Generate("models", nil)
+	require.NoError(t, err)
+	assert.Empty(t, typeCheck(t, src).Imports())
+}
+
+// typeCheck checks the generated source against the sdk
+func typeCheck(t *testing.T, src []byte) *types.Package {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "models.go", src, parser.ParseComments)
+	require.NoError(t, err)
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("models", fset, []*ast.File{file}, nil)
+	require.NoError(t, err)
+
+	return pkg
+}
@@ -0,0 +1,108 @@
+// Command contentful-gen generates Go structs from contentful content types.
+//
+// Content types are read from an exported JSON file:
+//
+//	contentful-gen -file content_types.json -package models -out models/contentful.go
+//
+// or fetched from the content management api:
+//
+//	CONTENTFUL_CMA_TOKEN=... contentful-gen -space <space> -environment master -package models
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/foomo/contentful"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, "contentful-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	file := flag.String("file", "", "read the content types from an exported JSON file")
+	spaceID := flag.String("space", "", "space id to fetch the content types from")
+	environment := flag.String("environment", "master", "environment to fetch the content types from")
+	// the default is not read from the environment, usage would print it
+	token := flag.String("token", "", "content management token, defaults to $CONTENTFUL_CMA_TOKEN")
+	pkg := flag.String("package", "models", "package name of the generated file")
+	out := flag.String("out", "", "output file, defaults to stdout")
+	flag.Parse()
+	if *token == "" {
+		*token = os.Getenv("CONTENTFUL_CMA_TOKEN")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var contentTypes []*contentful.ContentType
+	var err error
+	switch {
+	case *file != "":
+		contentTypes, err = readContentTypes(*file)
+	case *spaceID != "":
+		contentTypes, err = fetchContentTypes(ctx, *token, *spaceID, *environment)
+	default:
+		err = errors.New("either -file or -space is required")
+	}
+	if err != nil {
+		return err
+	}
+
+	src, err := Generate(*pkg, contentTypes)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+
+	return os.WriteFile(*out, src, 0o644)
+}
+
+// readContentTypes reads a collection response, e.g. testdata/content_types.json,
+// or a space export containing contentTypes
+func readContentTypes(path string) ([]*contentful.ContentType, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var export struct {
+		Items        []*contentful.ContentType `json:"items"`
+		ContentTypes []*contentful.ContentType `json:"contentTypes"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, err
+	}
+
+	return append(export.Items, export.ContentTypes...), nil
+}
+
+func fetchContentTypes(ctx context.Context, token, spaceID, environment string) ([]*contentful.ContentType, error) {
+	cma, err := contentful.New(contentful.APICMA, token, contentful.WithEnvironment(environment))
+	if err != nil {
+		return nil, err
+	}
+
+	var contentTypes []*contentful.ContentType
+	for contentType, err := range cma.ContentTypes.List(ctx, spaceID).All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		contentTypes = append(contentTypes, &contentType)
+	}
+
+	return contentTypes, nil
+}
@@ -1,10 +1,12 @@
 package contentful
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
+	"errors"
+	"iter"
 	"net/http"
 	"regexp"
+	"strconv"
 )
 
 // CollectionOptions holds init options
@@ -12,27 +14,21 @@ type CollectionOptions struct {
 	Limit uint16
 }
 
-// Includes model
-type Includes struct {
-	Entry map[string]interface{} `json:"Entry"`
-	Asset map[string]interface{} `json:"Asset"`
-}
-
 // Collection model with generic type parameter for Items
 type Collection[T any] struct {
 	Query
 	c           *Contentful
 	req         *http.Request
-	page        uint16
-	Sys         *Sys                   `json:"sys"`
-	Total       int                    `json:"total"`
-	Skip        int                    `json:"skip"`
-	Limit       uint16                 `json:"limit"`
-	Items       []T                    `json:"items"`
-	Includes    map[string]interface{} `json:"includes"`
-	NextSyncURL string                 `json:"nextSyncUrl"`
-	NextPageURL string                 `json:"nextPageUrl"`
-	SyncToken   string                 `json:"syncToken"`
+	offset      int
+	Sys         *Sys      `json:"sys"`
+	Total       int       `json:"total"`
+	Skip        int       `json:"skip"`
+	Limit       uint16    `json:"limit"`
+	Items       []T       `json:"items"`
+	Includes    *Includes `json:"includes"`
+	NextSyncURL string    `json:"nextSyncUrl"`
+	NextPageURL string    `json:"nextPageUrl"`
+	SyncToken   string    `json:"syncToken"`
 	// Errors which occur in the contentful structure. They are not checked in
 	// this source code. Please do it yourself as you might still want to parse
 	// the result despite the error.
@@ -41,6 +37,18 @@ type Collection[T any] struct {
 	Details *ErrorDetails `json:"details"`
 }
 
+// rawQuery encodes the query with the given skip, which can exceed the range
+// of Query.Skip
+func rawQuery(q *Query, skip int) string {
+	params := q.Values()
+	params.Del("skip")
+	if skip > 0 {
+		params.Set("skip", strconv.Itoa(skip))
+	}
+
+	return params.Encode()
+}
+
 var syncTokenRegex = regexp.MustCompile(`sync_token=([a-zA-Z0-9_\-]+)`)
 
 // NewCollection initializes a new generic collection
@@ -54,29 +62,30 @@ func NewCollection[T any](options *CollectionOptions) *Collection[T] {
 
 	return &Collection[T]{
 		Query: *query,
-		page:  1,
 		Limit: limit,
 	}
 }
 
 // Next makes the col.req
 func (col *Collection[T]) Next() (*Collection[T], error) {
-	// setup query params
+	// setup query params, a sync token encodes its position so sync
+	// requests never skip
+	skip := col.offset
 	if col.SyncToken != "" {
 		col.Query = *NewQuery()
 		col.Query.SyncToken(col.SyncToken)
-	} else {
-		skip := col.limit * (col.page - 1)
-		col.Query.Skip(skip)
+		skip = 0
 	}
 
 	// override request query
-	col.req.URL.RawQuery = col.String()
+	col.req.URL.RawQuery = rawQuery(&col.Query, skip)
 	col.Sys = nil
 	col.Items = nil
 	col.Includes = nil
 	col.Errors = nil
 	col.Details = nil
+	col.NextPageURL = ""
+	col.NextSyncURL = ""
 
 	// makes api call
 	err := col.c.do(col.req, &col)
@@ -84,13 +93,16 @@ func (col *Collection[T]) Next() (*Collection[T], error) {
 		return nil, err
 	}
 
-	col.page++
 	if col.NextPageURL != "" {
 		syncToken := syncTokenRegex.FindStringSubmatch(col.NextPageURL)
 		col.SyncToken = syncToken[1]
 	} else if col.NextSyncURL != "" {
 		syncToken := syncTokenRegex.FindStringSubmatch(col.NextSyncURL)
 		col.SyncToken = syncToken[1]
+	} else {
+		// continue after the items returned, pages might be smaller than
+		// the limit
+		col.offset += len(col.Items)
 	}
 	return col, nil
 }
@@ -112,103 +124,145 @@ func (col *Collection[T]) Get() (*Collection[T], error) {
 func (col *Collection[T]) GetAll() (*Collection[T], error) {
 	var allItems []T
 	col.Query.Limit(col.Limit)
-	for {
-		var errNext error
-		col, errNext = col.Next()
-		if errNext != nil {
-			return nil, errNext
-		}
-		allItems = append(allItems, col.Items...)
-		if len(col.Items) < int(col.Limit) {
-			break
+	for page, err := range col.Pages(col.context()) {
+		if err != nil {
+			return nil, err
 		}
+		allItems = append(allItems, page.Items...)
 	}
 	col.Items = allItems
 	return col, nil
 }
 
-// ToIncludesEntry cast includesEntry to Entry model
-func (col *Collection[T]) ToIncludesEntry() ([]*Entry, error) {
-	var includesEntry []*Entry
+// Pages returns an iterator fetching one page after the other. The
+// collection itself is yielded and reused for every page. Iteration stops
+// after the last page, on the first error or when the loop is left.
+func (col *Collection[T]) Pages(ctx context.Context) iter.Seq2[*Collection[T], error] {
+	return func(yield func(*Collection[T], error) bool) {
+		if err := col.bind(ctx); err != nil {
+			yield(nil, err)
+			return
+		}
 
-	byteArray, err := json.Marshal(col.Includes["Entry"])
-	if err != nil {
-		return nil, err
+		for {
+			if _, err := col.Next(); err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(col, nil) || col.last() {
+				return
+			}
+		}
 	}
-	if err := json.NewDecoder(bytes.NewReader(byteArray)).Decode(&includesEntry); err != nil {
-		return nil, err
+}
+
+// All returns an iterator over the items of all pages. Pages are fetched
+// lazily, breaking out of the loop stops fetching.
+func (col *Collection[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page, err := range col.Pages(ctx) {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
 	}
+}
 
-	return includesEntry, nil
+// last reports whether the current page is the last one
+func (col *Collection[T]) last() bool {
+	switch {
+	case col.NextSyncURL != "":
+		// sync finished, the next sync token is stored in the collection
+		return true
+	case col.NextPageURL != "":
+		return false
+	default:
+		return len(col.Items) == 0 || col.offset >= col.Total
+	}
 }
 
-// ToIncludesEntryMap returns a map of Entry's from the Includes
-func (col *Collection[T]) ToIncludesEntryMap() (map[string]*Entry, error) {
-	var includesEntry []*Entry
-	includesEntryMap := make(map[string]*Entry)
+// bind makes the request use ctx, keeping the operation of the service method
+func (col *Collection[T]) bind(ctx context.Context) error {
+	if col.req == nil {
+		return errors.New("collection has no request")
+	}
 
-	byteArray, err := json.Marshal(col.Includes["Entry"])
-	if err != nil {
-		return nil, err
+	if op, ok := OperationFromContext(col.req.Context()); ok {
+		ctx = context.WithValue(ctx, operationKey{}, op)
 	}
-	if err := json.NewDecoder(bytes.NewReader(byteArray)).Decode(&includesEntry); err != nil {
-		return nil, err
+	col.req = col.req.WithContext(ctx)
+
+	return nil
+}
+
+func (col *Collection[T]) context() context.Context {
+	if col.req == nil {
+		return context.Background()
 	}
 
-	for _, e := range includesEntry {
-		includesEntryMap[e.Sys.ID] = e
+	return col.req.Context()
+}
+
+// ToIncludesEntry returns the included entries
+func (col *Collection[T]) ToIncludesEntry() ([]*Entry, error) {
+	if col.Includes == nil {
+		return nil, nil
 	}
-	return includesEntryMap, nil
+
+	return col.Includes.Entry, nil
 }
 
-// ToIncludesAsset cast includesAsset to Asset model
-func (col *Collection[T]) ToIncludesAsset() ([]*IncludeAsset, error) {
-	var includesAsset []*IncludeAsset
+// ToIncludesEntryMap returns a map of Entry's from the Includes. The map is
+// shared with the Includes and must not be modified.
+func (col *Collection[T]) ToIncludesEntryMap() (map[string]*Entry, error) {
+	if col.Includes == nil {
+		return map[string]*Entry{}, nil
+	}
 
-	byteArray, err := json.Marshal(col.Includes["Asset"])
-	if err != nil {
-		return nil, err
+	col.Includes.index()
+	return col.Includes.entries, nil
+}
+
+// ToIncludesAsset returns the included single locale assets
+func (col *Collection[T]) ToIncludesAsset() ([]*IncludeAsset, error) {
+	if col.Includes == nil {
+		return nil, nil
 	}
-	if err := json.NewDecoder(bytes.NewReader(byteArray)).Decode(&includesAsset); err != nil {
-		return nil, err
+	if col.Includes.localized {
+		return nil, errIncludesLocalized
 	}
-	return includesAsset, nil
+
+	return col.Includes.includeAssets, nil
 }
 
-// ToIncludesAssetMap returns a map of Asset's from the Includes
+// ToIncludesAssetMap returns a map of single locale Asset's from the Includes
 func (col *Collection[T]) ToIncludesAssetMap() (map[string]*IncludeAsset, error) {
-	var includesAsset []*IncludeAsset
-	includesAssetMap := make(map[string]*IncludeAsset)
-
-	byteArray, err := json.Marshal(col.Includes["Asset"])
+	includesAsset, err := col.ToIncludesAsset()
 	if err != nil {
 		return nil, err
 	}
-	if err := json.NewDecoder(bytes.NewReader(byteArray)).Decode(&includesAsset); err != nil {
-		return nil, err
-	}
 
+	includesAssetMap := make(map[string]*IncludeAsset, len(includesAsset))
 	for _, a := range includesAsset {
 		includesAssetMap[a.Sys.ID] = a
 	}
 	return includesAssetMap, nil
 }
 
-// ToIncludesLocalizedAssetMap returns a map of Asset's from the Includes
+// ToIncludesLocalizedAssetMap returns a map of Asset's from the Includes.
+// The map is shared with the Includes and must not be modified.
 func (col *Collection[T]) ToIncludesLocalizedAssetMap() (map[string]*Asset, error) {
-	var includesAsset []*Asset
-	includesAssetMap := make(map[string]*Asset)
-
-	byteArray, err := json.Marshal(col.Includes["Asset"])
-	if err != nil {
-		return nil, err
-	}
-	if err := json.NewDecoder(bytes.NewReader(byteArray)).Decode(&includesAsset); err != nil {
-		return nil, err
+	if col.Includes == nil {
+		return map[string]*Asset{}, nil
 	}
 
-	for _, a := range includesAsset {
-		includesAssetMap[a.Sys.ID] = a
-	}
-	return includesAssetMap, nil
+	col.Includes.index()
+	return col.Includes.assets, nil
 }
@@ -0,0 +1,135 @@
+package contentful
+
+import (
+	"context"
+	"iter"
+)
+
+type collectionPage[T any] struct {
+	items []T
+	err   error
+}
+
+// GetAllParallel is GetAll fetching up to workers pages concurrently - beware
+// of memory usage!
+func (col *Collection[T]) GetAllParallel(ctx context.Context, workers int) (*Collection[T], error) {
+	var allItems []T
+	for item, err := range col.AllParallel(ctx, workers) {
+		if err != nil {
+			return nil, err
+		}
+		allItems = append(allItems, item)
+	}
+	col.Items = allItems
+	return col, nil
+}
+
+// AllParallel returns an iterator over the items of all pages. The first page
+// is fetched to read Total, the remaining pages are fetched by up to workers
+// concurrent requests, which all respect the rate limiter of the client.
+// Items are yielded in order and at most workers pages are held in memory.
+// Sync collections are fetched sequentially.
+func (col *Collection[T]) AllParallel(ctx context.Context, workers int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if workers < 1 {
+			workers = 1
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		if err := col.bind(ctx); err != nil {
+			yield(zero, err)
+			return
+		}
+		// prefetched pages carry the operation of the service method, as
+		// the pages fetched by Next
+		ctx = col.req.Context()
+		if _, err := col.Next(); err != nil {
+			yield(zero, err)
+			return
+		}
+		for _, item := range col.Items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+		if col.last() {
+			return
+		}
+
+		if col.NextPageURL != "" {
+			// sync pages are chained by tokens and can't be prefetched
+			for item, err := range col.All(ctx) {
+				if !yield(item, err) {
+					return
+				}
+			}
+			return
+		}
+
+		step := int(col.Limit)
+		if step == 0 {
+			step = len(col.Items)
+		}
+
+		// the queue holds the pages in order, its capacity bounds the
+		// number of pages fetched ahead of the consumer
+		queue := make(chan chan collectionPage[T], workers-1)
+		start, total := col.offset, col.Total
+		go func() {
+			defer close(queue)
+			for offset := start; offset < total; offset += step {
+				page := make(chan collectionPage[T], 1)
+				select {
+				case queue <- page:
+				case <-ctx.Done():
+					return
+				}
+				go func() {
+					items, err := col.fetchRange(ctx, offset, min(offset+step, total))
+					page <- collectionPage[T]{items: items, err: err}
+				}()
+			}
+		}()
+
+		for page := range queue {
+			result := <-page
+			if result.err != nil {
+				yield(zero, result.err)
+				return
+			}
+			for _, item := range result.items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// fetchRange fetches the items from skip offset from up to to. Requests are
+// repeated if the api returns less items than requested.
+func (col *Collection[T]) fetchRange(ctx context.Context, from, to int) ([]T, error) {
+	var items []T
+	for from < to {
+		query := col.Query
+		query.Limit(uint16(to - from))
+
+		req := col.req.Clone(ctx)
+		req.URL.RawQuery = rawQuery(&query, from)
+
+		page := &Collection[T]{}
+		if err := col.c.do(req, page); err != nil {
+			return nil, err
+		}
+		if len(page.Items) == 0 {
+			break
+		}
+		items = append(items, page.Items...)
+		from += len(page.Items)
+	}
+
+	return items, nil
+}
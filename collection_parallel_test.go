@@ -0,0 +1,117 @@
+package contentful
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newParallelServer(t *testing.T, total int, requests, inFlight, maxInFlight *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(rand.IntN(5)) * time.Millisecond)
+
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		limit = min(limit, 5)
+		// pages are smaller than the limit
+		var items []string
+		for i := skip; i < total && len(items) < min(limit, 4); i++ {
+			items = append(items, fmt.Sprintf(`{"sys":{"id":"entry-%d"}}`, i))
+		}
+		_, _ = fmt.Fprintf(w, `{"sys":{"type":"Array"},"total":%d,"skip":%d,"limit":%d,"items":[%s]}`,
+			total, skip, limit, strings.Join(items, ","))
+	}))
+}
+
+func TestCollectionAllParallel(t *testing.T) {
+	t.Run("ordered", func(t *testing.T) {
+		var requests, inFlight, maxInFlight atomic.Int32
+		server := newParallelServer(t, 83, &requests, &inFlight, &maxInFlight)
+		defer server.Close()
+
+		cma = NewCMA(CMAToken)
+		cma.BaseURL = server.URL
+
+		col, err := cma.Entries.List(t.Context(), spaceID).GetAllParallel(t.Context(), 4)
+		require.NoError(t, err)
+		require.Len(t, col.Items, 83)
+		for i, entry := range col.Items {
+			assert.Equal(t, fmt.Sprintf("entry-%d", i), entry.Sys.ID)
+		}
+		assert.LessOrEqual(t, maxInFlight.Load(), int32(4))
+		assert.Greater(t, maxInFlight.Load(), int32(1))
+	})
+	t.Run("break", func(t *testing.T) {
+		var requests, inFlight, maxInFlight atomic.Int32
+		server := newParallelServer(t, 1000, &requests, &inFlight, &maxInFlight)
+		defer server.Close()
+
+		cma = NewCMA(CMAToken)
+		cma.BaseURL = server.URL
+
+		var count int
+		for _, err := range cma.Entries.List(t.Context(), spaceID).AllParallel(t.Context(), 2) {
+			require.NoError(t, err)
+			if count++; count == 10 {
+				break
+			}
+		}
+		assert.Less(t, requests.Load(), int32(10))
+	})
+	t.Run("rate limiter", func(t *testing.T) {
+		var requests, inFlight, maxInFlight atomic.Int32
+		server := newParallelServer(t, 40, &requests, &inFlight, &maxInFlight)
+		defer server.Close()
+
+		limiter := NewRateLimiter(1000, 1)
+		c, err := New(APICMA, CMAToken, WithBaseURL(server.URL), WithRateLimiter(limiter))
+		require.NoError(t, err)
+
+		col, err := c.Entries.List(t.Context(), spaceID).GetAllParallel(t.Context(), 8)
+		require.NoError(t, err)
+		assert.Len(t, col.Items, 40)
+		assert.Equal(t, int64(requests.Load()), limiter.Stats().Requests)
+	})
+	t.Run("operation", func(t *testing.T) {
+		var requests, inFlight, maxInFlight atomic.Int32
+		server := newParallelServer(t, 40, &requests, &inFlight, &maxInFlight)
+		defer server.Close()
+
+		var operations, missing atomic.Int32
+		c, err := New(APICMA, CMAToken, WithBaseURL(server.URL), WithMiddleware(func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				if op, ok := OperationFromContext(req.Context()); ok && op.Service == "Entries" && op.Name == "List" {
+					operations.Add(1)
+				} else {
+					missing.Add(1)
+				}
+				return next.Do(req)
+			})
+		}))
+		require.NoError(t, err)
+
+		_, err = c.Entries.List(t.Context(), spaceID).GetAllParallel(t.Context(), 4)
+		require.NoError(t, err)
+		assert.Equal(t, requests.Load(), operations.Load())
+		assert.Zero(t, missing.Load(), "every page carries the operation")
+	})
+}
@@ -2,10 +2,14 @@ package contentful
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -138,3 +142,117 @@ func (rt roundTrip) RoundTrip(*http.Request) (*http.Response, error) {
 		Body:       f,
 	}, nil
 }
+
+func newPagedServer(t *testing.T, total, pageSize int) (*httptest.Server, *[]string) {
+	t.Helper()
+	var skips []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		skips = append(skips, r.URL.Query().Get("skip"))
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		var items []string
+		// the server returns less items than requested
+		for i := skip; i < total && len(items) < pageSize; i++ {
+			items = append(items, fmt.Sprintf(`{"sys":{"id":"entry-%d"}}`, i))
+		}
+		_, _ = fmt.Fprintf(w, `{"sys":{"type":"Array"},"total":%d,"skip":%d,"limit":3,"items":[%s]}`,
+			total, skip, strings.Join(items, ","))
+	}))
+	return server, &skips
+}
+
+func TestCollectionAll(t *testing.T) {
+	t.Run("all pages", func(t *testing.T) {
+		server, skips := newPagedServer(t, 5, 2)
+		defer server.Close()
+
+		cma = NewCMA(CMAToken)
+		cma.BaseURL = server.URL
+
+		var ids []string
+		for entry, err := range cma.Entries.List(t.Context(), spaceID).All(t.Context()) {
+			require.NoError(t, err)
+			ids = append(ids, entry.Sys.ID)
+		}
+		assert.Equal(t, []string{"entry-0", "entry-1", "entry-2", "entry-3", "entry-4"}, ids)
+		assert.Equal(t, []string{"", "2", "4"}, *skips)
+	})
+	t.Run("break", func(t *testing.T) {
+		server, skips := newPagedServer(t, 5, 2)
+		defer server.Close()
+
+		cma = NewCMA(CMAToken)
+		cma.BaseURL = server.URL
+
+		var ids []string
+		for entry, err := range cma.Entries.List(t.Context(), spaceID).All(t.Context()) {
+			require.NoError(t, err)
+			ids = append(ids, entry.Sys.ID)
+			if len(ids) == 3 {
+				break
+			}
+		}
+		assert.Len(t, ids, 3)
+		assert.Equal(t, []string{"", "2"}, *skips)
+	})
+	t.Run("GetAll", func(t *testing.T) {
+		server, _ := newPagedServer(t, 5, 2)
+		defer server.Close()
+
+		cma = NewCMA(CMAToken)
+		cma.BaseURL = server.URL
+
+		col, err := cma.Entries.List(t.Context(), spaceID).GetAll()
+		require.NoError(t, err)
+		assert.Len(t, col.Items, 5)
+	})
+	t.Run("error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"sys":{"type":"Error","id":"NotFound"},"message":"not found"}`))
+		}))
+		defer server.Close()
+
+		cma = NewCMA(CMAToken)
+		cma.BaseURL = server.URL
+
+		var errs []error
+		for _, err := range cma.Entries.List(t.Context(), spaceID).All(t.Context()) {
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 1)
+		assert.IsType(t, NotFoundError{}, errs[0])
+	})
+}
+
+func TestCollectionAllSync(t *testing.T) {
+	var tokens, queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("sync_token")
+		tokens = append(tokens, token)
+		queries = append(queries, r.URL.RawQuery)
+		switch token {
+		case "":
+			_, _ = w.Write([]byte(`{"items":[{"sys":{"id":"a"}},{"sys":{"id":"b"}}],"nextPageUrl":"https://cdn.contentful.com/spaces/id1/sync?sync_token=page2"}`))
+		case "page2":
+			_, _ = w.Write([]byte(`{"items":[{"sys":{"id":"c"}}],"nextSyncUrl":"https://cdn.contentful.com/spaces/id1/sync?sync_token=next"}`))
+		default:
+			t.Errorf("unexpected sync token %q", token)
+		}
+	}))
+	defer server.Close()
+
+	cda := NewCDA(CMAToken)
+	cda.BaseURL = server.URL
+
+	col := cda.Entries.Sync(t.Context(), spaceID, true)
+	var ids []string
+	for entry, err := range col.All(t.Context()) {
+		require.NoError(t, err)
+		ids = append(ids, entry.Sys.ID)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+	assert.Equal(t, []string{"", "page2"}, tokens)
+	// the sync token encodes the position, the second page does not skip
+	assert.Equal(t, "sync_token=page2", queries[1])
+	assert.Equal(t, "next", col.SyncToken)
+}
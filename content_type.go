@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+
+	"github.com/foomo/contentful/richtext"
 )
 
 // ContentTypesService service
@@ -36,6 +38,9 @@ const (
 	// FieldTypeInteger content type field type for integer data
 	FieldTypeInteger = "Integer"
 
+	// FieldTypeNumber content type field type for decimal data
+	FieldTypeNumber = "Number"
+
 	// FieldTypeLocation content type field type for location data
 	FieldTypeLocation = "Location"
 
@@ -47,6 +52,9 @@ const (
 
 	// FieldTypeObject content type field type for object data
 	FieldTypeObject = "Object"
+
+	// FieldTypeRichText content type field type for rich text documents
+	FieldTypeRichText = "RichText"
 )
 
 // Field model
@@ -61,6 +69,8 @@ type Field struct {
 	Disabled    bool                `json:"disabled,omitempty"`
 	Omitted     bool                `json:"omitted,omitempty"`
 	Validations []FieldValidation   `json:"validations,omitempty"`
+	// NewID changes the id of the field with the next update
+	NewID string `json:"newId,omitempty"`
 }
 
 // UnmarshalJSON for custom json unmarshaling
@@ -123,6 +133,45 @@ func (field *Field) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// RichTextConstraints returns the node and mark types enabled by the
+// validations of a rich text field, for use with richtext.FromMarkdown and
+// richtext.FromHTML
+func (field *Field) RichTextConstraints() *richtext.Constraints {
+	constraints := &richtext.Constraints{}
+	for _, validation := range field.Validations {
+		switch v := validation.(type) {
+		case FieldValidationEnabledNodeTypes:
+			constraints.NodeTypes = enabledNodeTypes(v.EnabledNodeTypes)
+		case *FieldValidationEnabledNodeTypes:
+			constraints.NodeTypes = enabledNodeTypes(v.EnabledNodeTypes)
+		case FieldValidationEnabledMarks:
+			constraints.Marks = enabledMarks(v.EnabledMarks)
+		case *FieldValidationEnabledMarks:
+			constraints.Marks = enabledMarks(v.EnabledMarks)
+		}
+	}
+
+	return constraints
+}
+
+func enabledNodeTypes(values []string) []richtext.NodeType {
+	nodeTypes := make([]richtext.NodeType, 0, len(values))
+	for _, value := range values {
+		nodeTypes = append(nodeTypes, richtext.NodeType(value))
+	}
+
+	return nodeTypes
+}
+
+func enabledMarks(values []string) []richtext.MarkType {
+	marks := make([]richtext.MarkType, 0, len(values))
+	for _, value := range values {
+		marks = append(marks, richtext.MarkType(value))
+	}
+
+	return marks
+}
+
 // ParseValidations converts json representation to go struct
 func ParseValidations(data []interface{}) ([]FieldValidation, error) {
 	var validations []FieldValidation
@@ -246,6 +295,26 @@ func ParseValidations(data []interface{}) ([]FieldValidation, error) {
 
 			validations = append(validations, fieldValidationRegex)
 		}
+
+		if _, ok := validation["enabledNodeTypes"]; ok {
+			var fieldValidationEnabledNodeTypes FieldValidationEnabledNodeTypes
+			if err := Decode(buf, &fieldValidationEnabledNodeTypes); err != nil {
+				done()
+				return nil, err
+			}
+
+			validations = append(validations, fieldValidationEnabledNodeTypes)
+		}
+
+		if _, ok := validation["enabledMarks"]; ok {
+			var fieldValidationEnabledMarks FieldValidationEnabledMarks
+			if err := Decode(buf, &fieldValidationEnabledMarks); err != nil {
+				done()
+				return nil, err
+			}
+
+			validations = append(validations, fieldValidationEnabledMarks)
+		}
 		done()
 	}
 
@@ -302,6 +371,7 @@ func (ct *ContentType) GetVersion() int {
 func (service *ContentTypesService) List(ctx context.Context, spaceID string) *Collection[ContentType] {
 	path := fmt.Sprintf("/spaces/%s%s/content_types", spaceID, getEnvPath(service.c))
 
+	ctx = service.c.operation(ctx, "ContentTypes", "List", spaceID, "")
 	req, err := service.c.newRequest(ctx, http.MethodGet, path, nil, nil, nil)
 	if err != nil {
 		return nil
@@ -318,6 +388,7 @@ func (service *ContentTypesService) List(ctx context.Context, spaceID string) *C
 func (service *ContentTypesService) Get(ctx context.Context, spaceID, contentTypeID string) (*ContentType, error) {
 	path := fmt.Sprintf("/spaces/%s%s/content_types/%s", spaceID, getEnvPath(service.c), contentTypeID)
 
+	ctx = service.c.operation(ctx, "ContentTypes", "Get", spaceID, contentTypeID)
 	req, err := service.c.newRequest(ctx, http.MethodGet, path, nil, nil, nil)
 	if err != nil {
 		return nil, err
@@ -349,6 +420,7 @@ func (service *ContentTypesService) Upsert(ctx context.Context, spaceID string,
 		method = http.MethodPost
 	}
 
+	ctx = service.c.operation(ctx, "ContentTypes", "Upsert", spaceID, sysID(ct.Sys))
 	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
 	if err != nil {
 		return err
@@ -364,6 +436,7 @@ func (service *ContentTypesService) Delete(ctx context.Context, spaceID string,
 	path := fmt.Sprintf("/spaces/%s%s/content_types/%s", spaceID, getEnvPath(service.c), ct.Sys.ID)
 	method := http.MethodDelete
 
+	ctx = service.c.operation(ctx, "ContentTypes", "Delete", spaceID, ct.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -379,6 +452,7 @@ func (service *ContentTypesService) Delete(ctx context.Context, spaceID string,
 func (service *ContentTypesService) Activate(ctx context.Context, spaceID string, ct *ContentType) error {
 	path := fmt.Sprintf("/spaces/%s%s/content_types/%s/published", spaceID, getEnvPath(service.c), ct.Sys.ID)
 
+	ctx = service.c.operation(ctx, "ContentTypes", "Activate", spaceID, ct.Sys.ID)
 	req, err := service.c.newRequest(ctx, http.MethodPut, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -393,6 +467,7 @@ func (service *ContentTypesService) Activate(ctx context.Context, spaceID string
 func (service *ContentTypesService) Deactivate(ctx context.Context, spaceID string, ct *ContentType) error {
 	path := fmt.Sprintf("/spaces/%s%s/content_types/%s/published", spaceID, getEnvPath(service.c), ct.Sys.ID)
 
+	ctx = service.c.operation(ctx, "ContentTypes", "Deactivate", spaceID, ct.Sys.ID)
 	req, err := service.c.newRequest(ctx, http.MethodDelete, path, nil, nil, nil)
 	if err != nil {
 		return err
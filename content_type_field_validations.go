@@ -56,10 +56,72 @@ type FieldValidationMimeType struct {
 	MimeTypes []string `json:"linkMimetypeGroup,omitempty"`
 }
 
-// MinMax model
+// MinMax model. A bound of 0 is only set when it was decoded from json or
+// set with SetMin or SetMax.
 type MinMax struct {
-	Min float64 `json:"min,omitempty"`
-	Max float64 `json:"max,omitempty"`
+	Min     float64 `json:"min,omitempty"`
+	Max     float64 `json:"max,omitempty"`
+	zeroMin bool
+	zeroMax bool
+}
+
+// SetMin sets the min bound, 0 included
+func (m *MinMax) SetMin(v float64) *MinMax {
+	m.Min, m.zeroMin = v, v == 0
+	return m
+}
+
+// SetMax sets the max bound, 0 included
+func (m *MinMax) SetMax(v float64) *MinMax {
+	m.Max, m.zeroMax = v, v == 0
+	return m
+}
+
+// HasMin reports whether the min bound is set
+func (m MinMax) HasMin() bool {
+	return m.Min != 0 || m.zeroMin
+}
+
+// HasMax reports whether the max bound is set
+func (m MinMax) HasMax() bool {
+	return m.Max != 0 || m.zeroMax
+}
+
+// MarshalJSON for custom json marshaling
+func (m MinMax) MarshalJSON() ([]byte, error) {
+	var payload struct {
+		Min *float64 `json:"min,omitempty"`
+		Max *float64 `json:"max,omitempty"`
+	}
+	if m.HasMin() {
+		payload.Min = &m.Min
+	}
+	if m.HasMax() {
+		payload.Max = &m.Max
+	}
+
+	return json.Marshal(payload)
+}
+
+// UnmarshalJSON for custom json unmarshaling
+func (m *MinMax) UnmarshalJSON(data []byte) error {
+	var payload struct {
+		Min *float64 `json:"min"`
+		Max *float64 `json:"max"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	*m = MinMax{}
+	if payload.Min != nil {
+		m.SetMin(*payload.Min)
+	}
+	if payload.Max != nil {
+		m.SetMax(*payload.Max)
+	}
+
+	return nil
 }
 
 // DateMinMax model
@@ -76,7 +138,7 @@ type FieldValidationDimension struct {
 }
 
 // MarshalJSON for custom json marshaling
-func (v *FieldValidationDimension) MarshalJSON() ([]byte, error) {
+func (v FieldValidationDimension) MarshalJSON() ([]byte, error) {
 	type dimension struct {
 		Width  *MinMax `json:"width,omitempty"`
 		Height *MinMax `json:"height,omitempty"`
@@ -107,11 +169,11 @@ func (v *FieldValidationDimension) UnmarshalJSON(data []byte) error {
 		v.Width = &MinMax{}
 
 		if minimum, ok := width["min"].(float64); ok {
-			v.Width.Min = minimum
+			v.Width.SetMin(minimum)
 		}
 
-		if maximum, ok := width["min"].(float64); ok {
-			v.Width.Max = maximum
+		if maximum, ok := width["max"].(float64); ok {
+			v.Width.SetMax(maximum)
 		}
 	}
 
@@ -119,11 +181,11 @@ func (v *FieldValidationDimension) UnmarshalJSON(data []byte) error {
 		v.Height = &MinMax{}
 
 		if minimum, ok := height["min"].(float64); ok {
-			v.Height.Min = minimum
+			v.Height.SetMin(minimum)
 		}
 
 		if maximum, ok := height["max"].(float64); ok {
-			v.Height.Max = maximum
+			v.Height.SetMax(maximum)
 		}
 	}
 
@@ -157,6 +219,9 @@ type FieldValidationRange struct {
 	ErrorMessage string  `json:"message,omitempty"`
 }
 
+// dateRangeLayout is the time layout of date range validations
+const dateRangeLayout = "2006-01-02T15:04:05"
+
 // FieldValidationDate model
 type FieldValidationDate struct {
 	Range        *DateMinMax `json:"dateRange,omitempty"`
@@ -164,21 +229,30 @@ type FieldValidationDate struct {
 }
 
 // MarshalJSON for custom json marshaling
-func (v *FieldValidationDate) MarshalJSON() ([]byte, error) {
+func (v FieldValidationDate) MarshalJSON() ([]byte, error) {
 	type dateRange struct {
 		Min string `json:"min,omitempty"`
 		Max string `json:"max,omitempty"`
 	}
 
+	var r *dateRange
+	if v.Range != nil {
+		// unset bounds are left out
+		r = &dateRange{}
+		if !v.Range.Min.IsZero() {
+			r.Min = v.Range.Min.Format(dateRangeLayout)
+		}
+		if !v.Range.Max.IsZero() {
+			r.Max = v.Range.Max.Format(dateRangeLayout)
+		}
+	}
+
 	return json.Marshal(&struct {
 		DateRange *dateRange `json:"dateRange,omitempty"`
 		Message   string     `json:"message,omitempty"`
 	}{
-		DateRange: &dateRange{
-			Min: v.Range.Max.Format("2006-01-02T03:04:05"),
-			Max: v.Range.Max.Format("2006-01-02T03:04:05"),
-		},
-		Message: v.ErrorMessage,
+		DateRange: r,
+		Message:   v.ErrorMessage,
 	})
 }
 
@@ -194,7 +268,7 @@ func (v *FieldValidationDate) UnmarshalJSON(data []byte) error {
 	v.Range = &DateMinMax{}
 
 	if minimum, ok := dateRangeData["min"].(string); ok {
-		minDate, err := time.Parse("2006-01-02T03:04:05", minimum)
+		minDate, err := time.Parse(dateRangeLayout, minimum)
 		if err != nil {
 			return err
 		}
@@ -203,7 +277,7 @@ func (v *FieldValidationDate) UnmarshalJSON(data []byte) error {
 	}
 
 	if maximum, ok := dateRangeData["max"].(string); ok {
-		maxDate, err := time.Parse("2006-01-02T03:04:05", maximum)
+		maxDate, err := time.Parse(dateRangeLayout, maximum)
 		if err != nil {
 			return err
 		}
@@ -261,3 +335,15 @@ type FieldValidationRegex struct {
 	Regex        *Regex `json:"regexp,omitempty"`
 	ErrorMessage string `json:"message,omitempty"`
 }
+
+// FieldValidationEnabledNodeTypes model restricts the node types of a rich text field
+type FieldValidationEnabledNodeTypes struct {
+	EnabledNodeTypes []string `json:"enabledNodeTypes"`
+	ErrorMessage     string   `json:"message,omitempty"`
+}
+
+// FieldValidationEnabledMarks model restricts the marks of a rich text field
+type FieldValidationEnabledMarks struct {
+	EnabledMarks []string `json:"enabledMarks"`
+	ErrorMessage string   `json:"message,omitempty"`
+}
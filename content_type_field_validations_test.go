@@ -130,7 +130,7 @@ func TestFieldValidationSize(t *testing.T) {
 func TestFieldValidationDate(t *testing.T) {
 	var err error
 
-	layout := "2006-01-02T03:04:05"
+	layout := "2006-01-02T15:04:05"
 	minimum := time.Now()
 	maximum := time.Now()
 
@@ -155,3 +155,61 @@ func TestFieldValidationDate(t *testing.T) {
 	assert.Equal(t, maxStr, validationCheck.Range.Max.Format(layout))
 	assert.Equal(t, "error message", validationCheck.ErrorMessage)
 }
+
+func TestFieldValidationDateRoundTrip(t *testing.T) {
+	for name, test := range map[string]struct {
+		data     string
+		expected string
+	}{
+		"min":       {`{"dateRange":{"min":"2000-01-01T00:00:00"}}`, `{"dateRange":{"min":"2000-01-01T00:00:00"}}`},
+		"afternoon": {`{"dateRange":{"min":"2000-01-01T13:00:00","max":"2000-12-31T23:59:59"}}`, `{"dateRange":{"min":"2000-01-01T13:00:00","max":"2000-12-31T23:59:59"}}`},
+		"max":       {`{"dateRange":{"max":"2000-12-31T12:30:00"},"message":"too late"}`, `{"dateRange":{"max":"2000-12-31T12:30:00"},"message":"too late"}`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var field Field
+			require.NoError(t, json.Unmarshal([]byte(`{"id":"date","type":"Date","validations":[`+test.data+`]}`), &field))
+			require.Len(t, field.Validations, 1)
+
+			data, err := json.Marshal(field.Validations[0])
+			require.NoError(t, err)
+			assert.JSONEq(t, test.expected, string(data))
+		})
+	}
+
+	data, err := json.Marshal(FieldValidationDate{ErrorMessage: "no range"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"message":"no range"}`, string(data))
+}
+
+func TestFieldValidationDimension(t *testing.T) {
+	data := `{"assetImageDimensions":{"width":{"min":10,"max":1000},"height":{"max":500}},"message":"too large"}`
+
+	var validation FieldValidationDimension
+	require.NoError(t, json.Unmarshal([]byte(data), &validation))
+	assert.InDelta(t, float64(10), validation.Width.Min, 0)
+	assert.InDelta(t, float64(1000), validation.Width.Max, 0)
+	assert.InDelta(t, float64(500), validation.Height.Max, 0)
+
+	// validations of fields are values, they marshal in the api shape as well
+	var field Field
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"photo","type":"Link","linkType":"Asset","validations":[`+data+`]}`), &field))
+	marshaled, err := json.Marshal(field.Validations)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[`+data+`]`, string(marshaled))
+}
+
+func TestMinMaxZero(t *testing.T) {
+	data, err := json.Marshal(FieldValidationRange{Range: (&MinMax{}).SetMin(0)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"range":{"min":0}}`, string(data))
+
+	var validation FieldValidationRange
+	require.NoError(t, json.Unmarshal(data, &validation))
+	assert.True(t, validation.Range.HasMin())
+	assert.False(t, validation.Range.HasMax())
+
+	// unset bounds are omitted
+	data, err = json.Marshal(FieldValidationRange{Range: &MinMax{Max: 9}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"range":{"max":9}}`, string(data))
+}
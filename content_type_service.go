@@ -54,6 +54,7 @@ func (service *ContentTypeService[T]) List(ctx context.Context, spaceID string)
 	path := fmt.Sprintf("/spaces/%s%s/entries", spaceID, getEnvPath(service.c))
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Entries", "List", spaceID, "")
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return &Collection[T]{}
@@ -71,6 +72,7 @@ func (service *ContentTypeService[T]) Sync(ctx context.Context, spaceID string,
 	path := fmt.Sprintf("/spaces/%s%s/sync", spaceID, getEnvPath(service.c))
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Entries", "Sync", spaceID, "")
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return &Collection[T]{}
@@ -99,6 +101,7 @@ func (service *ContentTypeService[T]) Get(ctx context.Context, spaceID, entryID
 	}
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Entries", "Get", spaceID, entryID)
 	req, err := service.c.newRequest(ctx, method, path, query, nil, nil)
 	if err != nil {
 		return entry, err
@@ -116,6 +119,7 @@ func (service *ContentTypeService[T]) Delete(ctx context.Context, spaceID string
 	path := fmt.Sprintf("/spaces/%s%s/entries/%s", spaceID, getEnvPath(service.c), entryID)
 	method := http.MethodDelete
 
+	ctx = service.c.operation(ctx, "Entries", "Delete", spaceID, entryID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -156,6 +160,7 @@ func (service *ContentTypeService[T]) Upsert(ctx context.Context, spaceID string
 		method = http.MethodPost
 	}
 
+	ctx = service.c.operation(ctx, "Entries", "Upsert", spaceID, sysID(base.Sys))
 	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
 	if err != nil {
 		return err
@@ -176,6 +181,7 @@ func (service *ContentTypeService[T]) Publish(ctx context.Context, spaceID strin
 	path := fmt.Sprintf("/spaces/%s%s/entries/%s/published", spaceID, getEnvPath(service.c), base.Sys.ID)
 	method := http.MethodPut
 
+	ctx = service.c.operation(ctx, "Entries", "Publish", spaceID, base.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -196,6 +202,7 @@ func (service *ContentTypeService[T]) Unpublish(ctx context.Context, spaceID str
 	path := fmt.Sprintf("/spaces/%s%s/entries/%s/published", spaceID, getEnvPath(service.c), base.Sys.ID)
 	method := http.MethodDelete
 
+	ctx = service.c.operation(ctx, "Entries", "Unpublish", spaceID, base.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -216,6 +223,7 @@ func (service *ContentTypeService[T]) Archive(ctx context.Context, spaceID strin
 	path := fmt.Sprintf("/spaces/%s%s/entries/%s/archived", spaceID, getEnvPath(service.c), base.Sys.ID)
 	method := http.MethodPut
 
+	ctx = service.c.operation(ctx, "Entries", "Archive", spaceID, base.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/foomo/contentful/richtext"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -958,3 +959,33 @@ func TestContentTypeFieldValidationsUnmarshal(t *testing.T) {
 		assert.True(t, ok)
 	}
 }
+
+func TestFieldRichTextConstraints(t *testing.T) {
+	var field Field
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "body",
+		"name": "Body",
+		"type": "RichText",
+		"validations": [
+			{"enabledNodeTypes": ["heading-2", "unordered-list", "hyperlink"], "message": "nodes"},
+			{"enabledMarks": ["bold"], "message": "marks"}
+		]
+	}`), &field))
+	require.Len(t, field.Validations, 2)
+
+	constraints := field.RichTextConstraints()
+	assert.Equal(t, []richtext.NodeType{"heading-2", "unordered-list", "hyperlink"}, constraints.NodeTypes)
+	assert.Equal(t, []richtext.MarkType{"bold"}, constraints.Marks)
+
+	_, err := richtext.FromMarkdown("## Cats\n\n- **Nyan** [cat](https://example.com)", constraints)
+	require.NoError(t, err)
+
+	_, err = richtext.FromMarkdown("# Cats\n\n_Nyan_", constraints)
+	var constraintErr *richtext.ConstraintError
+	require.ErrorAs(t, err, &constraintErr)
+	assert.Equal(t, []richtext.NodeType{richtext.NodeTypeHeading1}, constraintErr.NodeTypes)
+	assert.Equal(t, []richtext.MarkType{richtext.MarkItalic}, constraintErr.Marks)
+
+	// fields without validations enable everything
+	assert.NoError(t, (&Field{}).RichTextConstraints().Validate(richtext.NewDocument(richtext.NewNode(richtext.NodeTypeTable))))
+}
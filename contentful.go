@@ -3,41 +3,55 @@ package contentful
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"maps"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"path"
-	"strconv"
 	"strings"
 	"time"
-
-	"github.com/aoliveti/curling"
 )
 
 // Contentful model
 type Contentful struct {
-	client      *http.Client
-	api         string
-	token       string
-	Debug       bool
+	client *http.Client
+	api    string
+	token  string
+	Debug  bool
+	// QueryParams are sent with every request. Modifying the map while
+	// requests are running is not safe, prefer WithQueryParam.
 	QueryParams map[string]string
+	// Headers are sent with every request. Modifying the map while
+	// requests are running is not safe, prefer WithHeader.
 	Headers     map[string]string
 	BaseURL     string
 	UploadURL   string
 	Environment string
 
-	Spaces       *SpacesService
-	APIKeys      *APIKeyService
-	Assets       *AssetsService
-	ContentTypes *ContentTypesService
-	Entries      *EntriesService
-	Locales      *LocalesService
-	Tags         *TagsService
-	Upload       *UploadService
-	Webhooks     *WebhooksService
+	headers     map[string]string
+	queryParams map[string]string
+	spaceID     string
+	retryPolicy *RetryPolicy
+	rateLimiter *RateLimiter
+	middlewares []Middleware
+	hooks       []OperationHook
+	logger      *slog.Logger
+
+	Spaces           *SpacesService
+	APIKeys          *APIKeyService
+	Assets           *AssetsService
+	ContentTypes     *ContentTypesService
+	EditorInterfaces *EditorInterfacesService
+	Entries          *EntriesService
+	Environments     *EnvironmentsService
+	Aliases          *EnvironmentAliasesService
+	Locales          *LocalesService
+	Roles            *RolesService
+	Tags             *TagsService
+	Upload           *UploadService
+	Webhooks         *WebhooksService
 }
 
 type service struct {
@@ -53,83 +67,48 @@ func getEnvPath(c *Contentful) string {
 
 // NewCMA returns a CMA client
 func NewCMA(token string) *Contentful {
-	c := &Contentful{
-		client: http.DefaultClient,
-		api:    "CMA",
-		token:  token,
-		Debug:  false,
-		Headers: map[string]string{
-			"Authorization":           fmt.Sprintf("Bearer %s", token),
-			"Content-Type":            "application/vnd.contentful.management.v1+json",
-			"X-Contentful-User-Agent": fmt.Sprintf("sdk contentful.go/%s", Version),
-		},
-		BaseURL:   "https://api.contentful.com",
-		UploadURL: "https://upload.contentful.com",
-	}
-
-	c.Spaces = &SpacesService{c: c}
-	c.APIKeys = &APIKeyService{c: c}
-	c.Assets = &AssetsService{c: c}
-	c.ContentTypes = &ContentTypesService{c: c}
-	c.Entries = &EntriesService{c: c}
-	c.Tags = &TagsService{c: c}
-	c.Upload = &UploadService{c: c}
-	c.Locales = &LocalesService{c: c}
-	c.Webhooks = &WebhooksService{c: c}
-
-	return c
+	return newLegacy(APICMA, token)
 }
 
 // NewCDA returns a CDA client
 func NewCDA(token string) *Contentful {
-	c := &Contentful{
-		client: http.DefaultClient,
-		api:    "CDA",
-		token:  token,
-		Debug:  false,
-		Headers: map[string]string{
-			"Authorization":           "Bearer " + token,
-			"Content-Type":            "application/vnd.contentful.delivery.v1+json",
-			"X-Contentful-User-Agent": fmt.Sprintf("contentful-go/%s", Version),
-		},
-		BaseURL: "https://cdn.contentful.com",
-	}
-
-	c.Spaces = &SpacesService{c: c}
-	c.APIKeys = &APIKeyService{c: c}
-	c.Assets = &AssetsService{c: c}
-	c.ContentTypes = &ContentTypesService{c: c}
-	c.Entries = &EntriesService{c: c}
-	c.Tags = &TagsService{c: c}
-	c.Locales = &LocalesService{c: c}
-	c.Webhooks = &WebhooksService{c: c}
-
-	return c
+	return newLegacy(APICDA, token)
 }
 
 // NewCPA returns a CPA client
 func NewCPA(token string) *Contentful {
-	c := &Contentful{
-		client: http.DefaultClient,
-		Debug:  false,
-		api:    "CPA",
-		token:  token,
-		Headers: map[string]string{
-			"Authorization": "Bearer " + token,
-		},
-		BaseURL: "https://preview.contentful.com",
-	}
+	return newLegacy(APICPA, token)
+}
 
+// newLegacy returns an unvalidated client exposing its headers through the
+// mutable Headers map
+func newLegacy(api API, token string) *Contentful {
+	c := newContentful(api, token, &config{retryPolicy: DefaultRetryPolicy()})
+	c.Headers = maps.Clone(c.headers)
+	c.headers = nil
+	return c
+}
+
+// wire creates the services of the client
+func (c *Contentful) wire() {
 	c.Spaces = &SpacesService{c: c}
 	c.APIKeys = &APIKeyService{c: c}
 	c.Assets = &AssetsService{c: c}
 	c.ContentTypes = &ContentTypesService{c: c}
+	c.EditorInterfaces = &EditorInterfacesService{c: c}
 	c.Entries = &EntriesService{c: c}
-	c.Tags = &TagsService{c: c}
+	c.Environments = &EnvironmentsService{c: c}
+	c.Aliases = &EnvironmentAliasesService{c: c}
 	c.Locales = &LocalesService{c: c}
+	c.Roles = &RolesService{c: c}
+	c.Tags = &TagsService{c: c}
+	c.Upload = &UploadService{c: c}
 	c.Webhooks = &WebhooksService{c: c}
+}
 
-	return c
+// SpaceID returns the default space configured with WithSpace
+func (c *Contentful) SpaceID() string {
+	return c.spaceID
 }
 
 // SetRegion configures all API base URLs for the given region.
@@ -148,6 +127,9 @@ func (c *Contentful) SetRegion(region Region) *Contentful {
 
 // SetOrganization sets the given organization id
 func (c *Contentful) SetOrganization(organizationID string) *Contentful {
+	if c.Headers == nil {
+		c.Headers = map[string]string{}
+	}
 	c.Headers["X-Contentful-Organization"] = organizationID
 
 	return c
@@ -192,6 +174,12 @@ func (c *Contentful) newRequest(ctx context.Context, method, requestPath string,
 	}
 
 	// set query params
+	if query == nil {
+		query = url.Values{}
+	}
+	for key, value := range c.queryParams {
+		query.Set(key, value)
+	}
 	for key, value := range c.QueryParams {
 		query.Set(key, value)
 	}
@@ -205,6 +193,9 @@ func (c *Contentful) newRequest(ctx context.Context, method, requestPath string,
 	}
 
 	// set headers
+	for key, value := range c.headers {
+		req.Header.Set(key, value)
+	}
 	for key, value := range c.Headers {
 		req.Header.Set(key, value)
 	}
@@ -215,64 +206,71 @@ func (c *Contentful) newRequest(ctx context.Context, method, requestPath string,
 	return req, nil
 }
 
-func (c *Contentful) do(req *http.Request, v any) error {
-	if c.Debug {
-		if cmd, err := curling.NewFromRequest(req); err == nil {
-			fmt.Println(cmd)
+func (c *Contentful) do(req *http.Request, v any) (err error) {
+	if op, ok := OperationFromContext(req.Context()); ok {
+		for _, hook := range c.hooks {
+			ctx, end := hook(req.Context(), op)
+			req = req.WithContext(ctx)
+			defer func() { end(err) }()
 		}
 	}
 
-	res, err := c.client.Do(req)
-	if err != nil {
-		return err
+	policy := c.retryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
 	}
 
-	if res.StatusCode >= 200 && res.StatusCode < 400 {
-		if v != nil {
-			defer res.Body.Close()
-			err = json.NewDecoder(res.Body).Decode(v)
-			if err != nil {
+	for attempt := 1; ; attempt++ {
+		if c.rateLimiter != nil {
+			if err := c.rateLimiter.Wait(req.Context()); err != nil {
 				return err
 			}
 		}
 
-		return nil
-	}
-
-	// parse api response
-	apiError := c.handleError(req, res)
+		c.logRequest(req, attempt)
 
-	// return apiError if it is not rate limit error
-	var rateLimitExceededError RateLimitExceededError
-	if !errors.As(apiError, &rateLimitExceededError) {
-		return apiError
-	}
+		start := time.Now()
+		res, err := c.doer().Do(req)
+		c.logResponse(req, res, err, attempt, time.Since(start))
+		if err == nil && res.StatusCode >= 200 && res.StatusCode < 400 {
+			defer res.Body.Close()
+			if v != nil {
+				return json.NewDecoder(res.Body).Decode(v)
+			}
 
-	resetHeader := res.Header.Get("X-Contentful-Ratelimit-Reset")
+			return nil
+		}
 
-	// return apiError if Ratelimit-Reset header is not presented
-	if resetHeader == "" {
-		return apiError
-	}
+		if err == nil {
+			// parse api response
+			err = c.handleError(req, res)
+		}
 
-	// wait X-Contentful-Ratelimit-Reset amount of seconds
-	waitSeconds, err := strconv.Atoi(resetHeader)
-	if err != nil {
-		return apiError
-	}
+		if attempt >= policy.MaxAttempts || !policy.retryable(req, res, err) {
+			return err
+		}
 
-	time.Sleep(time.Second * time.Duration(waitSeconds))
+		next, ok := rewind(req)
+		if !ok {
+			return err
+		}
 
-	return c.do(req, v)
-}
+		wait := policy.Backoff(attempt, res)
+		if logger := c.log(); logger != nil {
+			logger.LogAttrs(req.Context(), slog.LevelInfo, "contentful retry",
+				append(requestAttrs(req, attempt), slog.Duration("wait", wait))...,
+			)
+		}
 
-func (c *Contentful) handleError(req *http.Request, res *http.Response) error {
-	if c.Debug {
-		if dump, err := httputil.DumpResponse(res, true); err == nil {
-			fmt.Printf("%q", dump)
+		if errSleep := sleep(req.Context(), wait); errSleep != nil {
+			return errSleep
 		}
+
+		req = next
 	}
+}
 
+func (c *Contentful) handleError(req *http.Request, res *http.Response) error {
 	var e ErrorResponse
 	defer res.Body.Close()
 	err := json.NewDecoder(res.Body).Decode(&e)
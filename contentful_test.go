@@ -138,7 +138,7 @@ func TestContentfulNewCMA(t *testing.T) {
 	assert.Equal(t, CMAToken, cma.token)
 	assert.Equal(t, fmt.Sprintf("Bearer %s", CMAToken), cma.Headers["Authorization"])
 	assert.Equal(t, "application/vnd.contentful.management.v1+json", cma.Headers["Content-Type"])
-	assert.Equal(t, fmt.Sprintf("sdk contentful.go/%s", Version), cma.Headers["X-Contentful-User-Agent"])
+	assert.Equal(t, userAgent("", ""), cma.Headers["X-Contentful-User-Agent"])
 }
 
 func TestContentfulNewCDA(t *testing.T) {
@@ -149,7 +149,7 @@ func TestContentfulNewCDA(t *testing.T) {
 	assert.Equal(t, CDAToken, cda.token)
 	assert.Equal(t, fmt.Sprintf("Bearer %s", CDAToken), cda.Headers["Authorization"])
 	assert.Equal(t, "application/vnd.contentful.delivery.v1+json", cda.Headers["Content-Type"])
-	assert.Equal(t, fmt.Sprintf("contentful-go/%s", Version), cda.Headers["X-Contentful-User-Agent"])
+	assert.Equal(t, userAgent("", ""), cda.Headers["X-Contentful-User-Agent"])
 }
 
 func TestContentfulNewCPA(t *testing.T) {
@@ -158,6 +158,9 @@ func TestContentfulNewCPA(t *testing.T) {
 	assert.Equal(t, "https://preview.contentful.com", cpa.BaseURL)
 	assert.Equal(t, "CPA", cpa.api)
 	assert.Equal(t, CPAToken, cpa.token)
+	assert.Equal(t, fmt.Sprintf("Bearer %s", CPAToken), cpa.Headers["Authorization"])
+	assert.Equal(t, "application/vnd.contentful.delivery.v1+json", cpa.Headers["Content-Type"])
+	assert.NotNil(t, cpa.Upload)
 }
 
 func TestContentfulSetOrganization(t *testing.T) {
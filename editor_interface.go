@@ -0,0 +1,117 @@
+package contentful
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// EditorInterfacesService service
+type EditorInterfacesService service
+
+// EditorInterface model
+type EditorInterface struct {
+	Sys           *Sys                      `json:"sys"`
+	Controls      []*EditorInterfaceControl `json:"controls,omitempty"`
+	Sidebar       []*EditorInterfaceWidget  `json:"sidebar,omitempty"`
+	Editors       []*EditorInterfaceWidget  `json:"editors,omitempty"`
+	EditorLayout  []interface{}             `json:"editorLayout,omitempty"`
+	GroupControls []interface{}             `json:"groupControls,omitempty"`
+}
+
+// EditorInterfaceControl configures the widget of a field
+type EditorInterfaceControl struct {
+	FieldID         string                 `json:"fieldId"`
+	WidgetID        string                 `json:"widgetId,omitempty"`
+	WidgetNamespace string                 `json:"widgetNamespace,omitempty"`
+	Settings        map[string]interface{} `json:"settings,omitempty"`
+}
+
+// EditorInterfaceWidget configures a sidebar widget or an entry editor
+type EditorInterfaceWidget struct {
+	WidgetID        string                 `json:"widgetId"`
+	WidgetNamespace string                 `json:"widgetNamespace"`
+	Settings        map[string]interface{} `json:"settings,omitempty"`
+	Disabled        bool                   `json:"disabled,omitempty"`
+}
+
+// GetVersion returns entity version
+func (ei *EditorInterface) GetVersion() int {
+	version := 1
+	if ei.Sys != nil {
+		version = ei.Sys.Version
+	}
+
+	return version
+}
+
+// ContentTypeID returns the id of the content type the editor interface belongs to
+func (ei *EditorInterface) ContentTypeID() string {
+	if ei.Sys == nil || ei.Sys.ContentType == nil || ei.Sys.ContentType.Sys == nil {
+		return ""
+	}
+
+	return ei.Sys.ContentType.Sys.ID
+}
+
+// List returns the editor interfaces of all content types
+func (service *EditorInterfacesService) List(ctx context.Context, spaceID string) *Collection[EditorInterface] {
+	path := fmt.Sprintf("/spaces/%s%s/editor_interfaces", spaceID, getEnvPath(service.c))
+	method := http.MethodGet
+
+	ctx = service.c.operation(ctx, "EditorInterfaces", "List", spaceID, "")
+	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
+	if err != nil {
+		return &Collection[EditorInterface]{}
+	}
+
+	col := NewCollection[EditorInterface](&CollectionOptions{})
+	col.c = service.c
+	col.req = req
+
+	return col
+}
+
+// Get returns the editor interface of a content type
+func (service *EditorInterfacesService) Get(ctx context.Context, spaceID, contentTypeID string) (*EditorInterface, error) {
+	path := fmt.Sprintf("/spaces/%s%s/content_types/%s/editor_interface", spaceID, getEnvPath(service.c), contentTypeID)
+	method := http.MethodGet
+
+	ctx = service.c.operation(ctx, "EditorInterfaces", "Get", spaceID, contentTypeID)
+	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ei *EditorInterface
+	if err := service.c.do(req, &ei); err != nil {
+		return nil, err
+	}
+
+	return ei, nil
+}
+
+// Update updates the editor interface of a content type. The editor
+// interface is created with the content type, so it can only be updated.
+func (service *EditorInterfacesService) Update(ctx context.Context, spaceID string, ei *EditorInterface) error {
+	contentTypeID := ei.ContentTypeID()
+	bytesArray, err := Marshal(ei)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/spaces/%s%s/content_types/%s/editor_interface", spaceID, getEnvPath(service.c), contentTypeID)
+	method := http.MethodPut
+
+	ctx = service.c.operation(ctx, "EditorInterfaces", "Update", spaceID, contentTypeID)
+	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Contentful-Version", strconv.Itoa(ei.GetVersion()))
+
+	return service.c.do(req, ei)
+}
@@ -62,6 +62,7 @@ func (service *EntriesService) List(ctx context.Context, spaceID string) *Collec
 	path := fmt.Sprintf("/spaces/%s%s/entries", spaceID, getEnvPath(service.c))
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Entries", "List", spaceID, "")
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return &Collection[Entry]{}
@@ -79,6 +80,7 @@ func (service *EntriesService) Sync(ctx context.Context, spaceID string, initial
 	path := fmt.Sprintf("/spaces/%s%s/sync", spaceID, getEnvPath(service.c))
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Entries", "Sync", spaceID, "")
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return &Collection[Entry]{}
@@ -106,6 +108,7 @@ func (service *EntriesService) Get(ctx context.Context, spaceID, entryID string,
 	}
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Entries", "Get", spaceID, entryID)
 	req, err := service.c.newRequest(ctx, method, path, query, nil, nil)
 	if err != nil {
 		return nil, err
@@ -124,6 +127,7 @@ func (service *EntriesService) Delete(ctx context.Context, spaceID string, entry
 	path := fmt.Sprintf("/spaces/%s%s/entries/%s", spaceID, getEnvPath(service.c), entryID)
 	method := http.MethodDelete
 
+	ctx = service.c.operation(ctx, "Entries", "Delete", spaceID, entryID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -132,11 +136,15 @@ func (service *EntriesService) Delete(ctx context.Context, spaceID string, entry
 	return service.c.do(req, nil)
 }
 
-// Upsert updates or creates a new entry
+// Upsert updates or creates a new entry. The metadata is sent along with the
+// fields if it is set, the tags of the entry are then replaced by its tags.
 func (service *EntriesService) Upsert(ctx context.Context, spaceID string, entry *Entry) error {
 	fieldsOnly := map[string]interface{}{
 		"fields": entry.Fields,
 	}
+	if entry.Metadata != nil {
+		fieldsOnly["metadata"] = entry.Metadata
+	}
 
 	bytesArray, err := Marshal(fieldsOnly)
 	if err != nil {
@@ -159,6 +167,7 @@ func (service *EntriesService) Upsert(ctx context.Context, spaceID string, entry
 		method = "POST"
 	}
 
+	ctx = service.c.operation(ctx, "Entries", "Upsert", spaceID, sysID(entry.Sys))
 	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
 	if err != nil {
 		return err
@@ -175,6 +184,7 @@ func (service *EntriesService) Publish(ctx context.Context, spaceID string, entr
 	path := fmt.Sprintf("/spaces/%s%s/entries/%s/published", spaceID, getEnvPath(service.c), entry.Sys.ID)
 	method := http.MethodPut
 
+	ctx = service.c.operation(ctx, "Entries", "Publish", spaceID, entry.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -191,6 +201,7 @@ func (service *EntriesService) Unpublish(ctx context.Context, spaceID string, en
 	path := fmt.Sprintf("/spaces/%s%s/entries/%s/published", spaceID, getEnvPath(service.c), entry.Sys.ID)
 	method := http.MethodDelete
 
+	ctx = service.c.operation(ctx, "Entries", "Unpublish", spaceID, entry.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -207,6 +218,7 @@ func (service *EntriesService) Archive(ctx context.Context, spaceID string, entr
 	path := fmt.Sprintf("/spaces/%s%s/entries/%s/archived", spaceID, getEnvPath(service.c), entry.Sys.ID)
 	method := http.MethodPut
 
+	ctx = service.c.operation(ctx, "Entries", "Archive", spaceID, entry.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
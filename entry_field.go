@@ -1,6 +1,10 @@
 package contentful
 
-import "reflect"
+import (
+	"reflect"
+
+	"github.com/foomo/contentful/richtext"
+)
 
 // EntryField model
 type EntryField struct {
@@ -78,6 +82,22 @@ func (ef *EntryField) LArray(locale string) []string {
 	panic("no such a locale")
 }
 
+// RichText converts interface to a rich text document
+func (ef *EntryField) RichText() (*richtext.Node, error) {
+	return richtext.FromValue(ef.value)
+}
+
+// LRichText returns the rich text document of the given locale
+func (ef *EntryField) LRichText(locale string) (*richtext.Node, error) {
+	m := ef.value.(map[string]interface{})
+
+	if val, ok := m[locale]; ok {
+		return richtext.FromValue(val)
+	}
+
+	panic("no such a locale")
+}
+
 // LinkID returns link model
 func (ef *EntryField) LinkID() string {
 	m := ef.value.(map[string]interface{})
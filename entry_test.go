@@ -249,3 +249,35 @@ func TestEntrySaveForCreate(t *testing.T) {
 //	cma.ContentTypes.Upsert("id1", ct)
 //	require.NoError(t, err)
 // }
+
+func TestEntryUpsertMetadata(t *testing.T) {
+	var payloads []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads = append(payloads, payload)
+		_, _ = fmt.Fprintln(w, readTestData(t, "entry_3.json"))
+	}))
+	defer server.Close()
+
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	newEntry := func() *Entry {
+		return &Entry{
+			Sys:    &Sys{ID: "foocat", Version: 1, ContentType: &ContentType{Sys: &Sys{ID: "cat"}}},
+			Fields: map[string]interface{}{"name": map[string]string{"en-US": "Foo Cat"}},
+		}
+	}
+
+	require.NoError(t, cma.Entries.Upsert(t.Context(), spaceID, newEntry()))
+	tagged := newEntry()
+	tagged.Metadata = &Metadata{Tags: []Tag{{Sys: &Sys{ID: "cute", Type: "Link", LinkType: "Tag"}}}}
+	require.NoError(t, cma.Entries.Upsert(t.Context(), spaceID, tagged))
+
+	require.Len(t, payloads, 2)
+	assert.NotContains(t, payloads[0], "metadata", "entries without metadata only send their fields")
+	assert.Equal(t, map[string]interface{}{
+		"tags": []interface{}{map[string]interface{}{"sys": map[string]interface{}{"id": "cute", "type": "Link", "linkType": "Tag"}}},
+	}, payloads[1]["metadata"])
+}
@@ -0,0 +1,186 @@
+package contentful
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// EnvironmentsService service
+type EnvironmentsService service
+
+const (
+	// EnvironmentStatusQueued environment is waiting to be created
+	EnvironmentStatusQueued = "queued"
+
+	// EnvironmentStatusReady environment can be used
+	EnvironmentStatusReady = "ready"
+
+	// EnvironmentStatusFailed environment creation failed
+	EnvironmentStatusFailed = "failed"
+)
+
+// EnvironmentPollInterval is the interval WaitReady polls the environment status
+var EnvironmentPollInterval = time.Second
+
+// Environment model
+type Environment struct {
+	Sys  *Sys   `json:"sys,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// GetVersion returns entity version
+func (environment *Environment) GetVersion() int {
+	version := 1
+	if environment.Sys != nil {
+		version = environment.Sys.Version
+	}
+
+	return version
+}
+
+// Status returns the status of the environment, e.g. EnvironmentStatusReady
+func (environment *Environment) Status() string {
+	if environment.Sys == nil || environment.Sys.Status == nil || environment.Sys.Status.Sys == nil {
+		return ""
+	}
+
+	return environment.Sys.Status.Sys.ID
+}
+
+// List returns an environments collection
+func (service *EnvironmentsService) List(ctx context.Context, spaceID string) *Collection[Environment] {
+	path := fmt.Sprintf("/spaces/%s/environments", spaceID)
+	method := http.MethodGet
+
+	ctx = service.c.operation(ctx, "Environments", "List", spaceID, "")
+	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
+	if err != nil {
+		return &Collection[Environment]{}
+	}
+
+	col := NewCollection[Environment](&CollectionOptions{})
+	col.c = service.c
+	col.req = req
+
+	return col
+}
+
+// Get returns a single environment entity
+func (service *EnvironmentsService) Get(ctx context.Context, spaceID, environmentID string) (*Environment, error) {
+	path := fmt.Sprintf("/spaces/%s/environments/%s", spaceID, environmentID)
+	method := http.MethodGet
+
+	ctx = service.c.operation(ctx, "Environments", "Get", spaceID, environmentID)
+	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var environment *Environment
+	if err := service.c.do(req, &environment); err != nil {
+		return nil, err
+	}
+
+	return environment, nil
+}
+
+// Create creates a new environment. The environment id is taken from
+// environment.Sys.ID if set. The content is cloned from sourceEnvironmentID,
+// or from master if it is empty. Use WaitReady to wait for the clone to finish.
+func (service *EnvironmentsService) Create(ctx context.Context, spaceID string, environment *Environment, sourceEnvironmentID string) error {
+	bytesArray, err := Marshal(map[string]string{
+		"name": environment.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	var path string
+	var method string
+
+	if environment.Sys != nil && environment.Sys.ID != "" {
+		path = fmt.Sprintf("/spaces/%s/environments/%s", spaceID, environment.Sys.ID)
+		method = http.MethodPut
+	} else {
+		path = fmt.Sprintf("/spaces/%s/environments", spaceID)
+		method = http.MethodPost
+	}
+
+	ctx = service.c.operation(ctx, "Environments", "Create", spaceID, sysID(environment.Sys))
+	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
+	if err != nil {
+		return err
+	}
+
+	if sourceEnvironmentID != "" {
+		req.Header.Set("X-Contentful-Source-Environment", sourceEnvironmentID)
+	}
+
+	return service.c.do(req, &environment)
+}
+
+// Update updates the name of the environment
+func (service *EnvironmentsService) Update(ctx context.Context, spaceID string, environment *Environment) error {
+	bytesArray, err := Marshal(map[string]string{
+		"name": environment.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/spaces/%s/environments/%s", spaceID, environment.Sys.ID)
+	method := http.MethodPut
+
+	ctx = service.c.operation(ctx, "Environments", "Update", spaceID, environment.Sys.ID)
+	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Contentful-Version", strconv.Itoa(environment.GetVersion()))
+
+	return service.c.do(req, &environment)
+}
+
+// Delete the environment
+func (service *EnvironmentsService) Delete(ctx context.Context, spaceID string, environment *Environment) error {
+	path := fmt.Sprintf("/spaces/%s/environments/%s", spaceID, environment.Sys.ID)
+	method := http.MethodDelete
+
+	ctx = service.c.operation(ctx, "Environments", "Delete", spaceID, environment.Sys.ID)
+	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	version := strconv.Itoa(environment.GetVersion())
+	req.Header.Set("X-Contentful-Version", version)
+
+	return service.c.do(req, nil)
+}
+
+// WaitReady polls the environment until its status is ready. An error is
+// returned if the environment failed or ctx is done.
+func (service *EnvironmentsService) WaitReady(ctx context.Context, spaceID, environmentID string) (*Environment, error) {
+	for {
+		environment, err := service.Get(ctx, spaceID, environmentID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch environment.Status() {
+		case EnvironmentStatusReady:
+			return environment, nil
+		case EnvironmentStatusFailed:
+			return environment, fmt.Errorf("environment %q failed", environmentID)
+		}
+
+		if err := sleep(ctx, EnvironmentPollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package contentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// EnvironmentAliasesService service
+type EnvironmentAliasesService service
+
+// EnvironmentAlias model
+type EnvironmentAlias struct {
+	Sys         *Sys  `json:"sys,omitempty"`
+	Environment *Link `json:"environment,omitempty"`
+}
+
+// SwitchAliasOptions configures EnvironmentAliasesService.Switch
+type SwitchAliasOptions struct {
+	// EnvironmentID of the environment to create, required
+	EnvironmentID string
+	// SourceEnvironmentID the new environment is cloned from, defaults to
+	// the current target of the alias
+	SourceEnvironmentID string
+	// Migrate is called once the new environment is ready and before the
+	// alias is switched
+	Migrate func(ctx context.Context, environment *EnvironmentClient) error
+	// DeleteOld deletes the previous target of the alias after the switch
+	DeleteOld bool
+}
+
+// MarshalJSON for custom json marshaling
+func (alias *EnvironmentAlias) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&struct {
+		Environment *Link `json:"environment"`
+	}{
+		Environment: alias.Environment,
+	})
+}
+
+// GetVersion returns entity version
+func (alias *EnvironmentAlias) GetVersion() int {
+	version := 1
+	if alias.Sys != nil {
+		version = alias.Sys.Version
+	}
+
+	return version
+}
+
+// EnvironmentID returns the id of the environment the alias points to
+func (alias *EnvironmentAlias) EnvironmentID() string {
+	if alias.Environment == nil {
+		return ""
+	}
+
+	return sysID(alias.Environment.Sys)
+}
+
+// List returns an environment aliases collection
+func (service *EnvironmentAliasesService) List(ctx context.Context, spaceID string) *Collection[EnvironmentAlias] {
+	path := fmt.Sprintf("/spaces/%s/environment_aliases", spaceID)
+	method := http.MethodGet
+
+	ctx = service.c.operation(ctx, "EnvironmentAliases", "List", spaceID, "")
+	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
+	if err != nil {
+		return &Collection[EnvironmentAlias]{}
+	}
+
+	col := NewCollection[EnvironmentAlias](&CollectionOptions{})
+	col.c = service.c
+	col.req = req
+
+	return col
+}
+
+// Get returns a single environment alias entity
+func (service *EnvironmentAliasesService) Get(ctx context.Context, spaceID, aliasID string) (*EnvironmentAlias, error) {
+	path := fmt.Sprintf("/spaces/%s/environment_aliases/%s", spaceID, aliasID)
+	method := http.MethodGet
+
+	ctx = service.c.operation(ctx, "EnvironmentAliases", "Get", spaceID, aliasID)
+	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var alias *EnvironmentAlias
+	if err := service.c.do(req, &alias); err != nil {
+		return nil, err
+	}
+
+	return alias, nil
+}
+
+// Create creates a new optional alias with the id alias.Sys.ID
+func (service *EnvironmentAliasesService) Create(ctx context.Context, spaceID string, alias *EnvironmentAlias) error {
+	return service.put(ctx, "Create", spaceID, alias, false)
+}
+
+// Update points the alias to alias.Environment. The request fails with a
+// VersionMismatchError if the alias has been changed in the meantime.
+func (service *EnvironmentAliasesService) Update(ctx context.Context, spaceID string, alias *EnvironmentAlias) error {
+	return service.put(ctx, "Update", spaceID, alias, true)
+}
+
+// Delete the optional alias
+func (service *EnvironmentAliasesService) Delete(ctx context.Context, spaceID string, alias *EnvironmentAlias) error {
+	path := fmt.Sprintf("/spaces/%s/environment_aliases/%s", spaceID, alias.Sys.ID)
+	method := http.MethodDelete
+
+	ctx = service.c.operation(ctx, "EnvironmentAliases", "Delete", spaceID, alias.Sys.ID)
+	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return service.c.do(req, nil)
+}
+
+// Switch creates a new environment, runs the migration on it and points the
+// alias to it. If the alias is updated concurrently to point to another
+// environment, the switch is aborted and the VersionMismatchError returned.
+func (service *EnvironmentAliasesService) Switch(ctx context.Context, spaceID, aliasID string, opts SwitchAliasOptions) (*EnvironmentAlias, error) {
+	if opts.EnvironmentID == "" {
+		return nil, errors.New("switching an alias requires an environment id")
+	}
+
+	alias, err := service.Get(ctx, spaceID, aliasID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := alias.EnvironmentID()
+	source := opts.SourceEnvironmentID
+	if source == "" {
+		source = previous
+	}
+
+	environment := &Environment{Sys: &Sys{ID: opts.EnvironmentID}, Name: opts.EnvironmentID}
+	if err := service.c.Environments.Create(ctx, spaceID, environment, source); err != nil {
+		return nil, err
+	}
+
+	if _, err := service.c.Environments.WaitReady(ctx, spaceID, opts.EnvironmentID); err != nil {
+		return nil, err
+	}
+
+	if opts.Migrate != nil {
+		if err := opts.Migrate(ctx, service.c.Space(spaceID).Environment(opts.EnvironmentID)); err != nil {
+			return nil, fmt.Errorf("migrating environment %q: %w", opts.EnvironmentID, err)
+		}
+	}
+
+	for {
+		alias.Environment = NewLink("Environment", opts.EnvironmentID)
+		err := service.Update(ctx, spaceID, alias)
+		if err == nil {
+			break
+		}
+
+		var versionMismatchError VersionMismatchError
+		if !errors.As(err, &versionMismatchError) {
+			return nil, err
+		}
+
+		// retry with the latest version unless someone else switched the alias
+		latest, errGet := service.Get(ctx, spaceID, aliasID)
+		if errGet != nil {
+			return nil, errGet
+		}
+		if latest.EnvironmentID() != previous || latest.GetVersion() == alias.GetVersion() {
+			return nil, err
+		}
+		alias = latest
+	}
+
+	if opts.DeleteOld && previous != "" && previous != opts.EnvironmentID {
+		// deleting requires the current version of the environment
+		old, err := service.c.Environments.Get(ctx, spaceID, previous)
+		if err != nil {
+			return alias, err
+		}
+		if err := service.c.Environments.Delete(ctx, spaceID, old); err != nil {
+			return alias, err
+		}
+	}
+
+	return alias, nil
+}
+
+func (service *EnvironmentAliasesService) put(ctx context.Context, name, spaceID string, alias *EnvironmentAlias, versioned bool) error {
+	bytesArray, err := Marshal(alias)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/spaces/%s/environment_aliases/%s", spaceID, alias.Sys.ID)
+	method := http.MethodPut
+
+	ctx = service.c.operation(ctx, "EnvironmentAliases", name, spaceID, alias.Sys.ID)
+	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
+	if err != nil {
+		return err
+	}
+
+	if versioned {
+		req.Header.Set("X-Contentful-Version", strconv.Itoa(alias.GetVersion()))
+	}
+
+	return service.c.do(req, &alias)
+}
@@ -0,0 +1,152 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func environmentAliasJSON(environmentID string, version int) string {
+	return fmt.Sprintf(`{
+		"sys": {"type": "EnvironmentAlias", "id": "master", "version": %[2]d},
+		"environment": {"sys": {"type": "Link", "linkType": "Environment", "id": %[1]q}}
+	}`, environmentID, version)
+}
+
+const versionMismatchJSON = `{"sys": {"type": "Error", "id": "VersionMismatch"}, "message": "version mismatch"}`
+
+func TestEnvironmentAliasesServiceUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/spaces/id1/environment_aliases/master", r.URL.Path)
+		assert.Equal(t, "3", r.Header.Get("X-Contentful-Version"))
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]any{
+			"environment": map[string]any{"sys": map[string]any{"type": "Link", "linkType": "Environment", "id": "staging"}},
+		}, payload)
+
+		_, _ = w.Write([]byte(environmentAliasJSON("staging", 4)))
+	}))
+	defer server.Close()
+
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	alias := &EnvironmentAlias{Sys: &Sys{ID: "master", Version: 3}, Environment: NewLink("Environment", "staging")}
+	require.NoError(t, cma.Space(spaceID).Aliases.Update(t.Context(), alias))
+	assert.Equal(t, 4, alias.GetVersion())
+	assert.Equal(t, "staging", alias.EnvironmentID())
+}
+
+func TestEnvironmentAliasesServiceSwitch(t *testing.T) {
+	interval := EnvironmentPollInterval
+	EnvironmentPollInterval = time.Millisecond
+	defer func() { EnvironmentPollInterval = interval }()
+
+	newServer := func(t *testing.T, concurrent string) (*httptest.Server, *[]string) {
+		t.Helper()
+		var mu sync.Mutex
+		var calls []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			switch r.Method + " " + r.URL.Path {
+			case "GET /spaces/id1/environment_aliases/master":
+				if len(calls) == 1 {
+					_, _ = w.Write([]byte(environmentAliasJSON("blue", 1)))
+					return
+				}
+				// the alias has been updated while migrating
+				target := "blue"
+				if concurrent != "" {
+					target = concurrent
+				}
+				_, _ = w.Write([]byte(environmentAliasJSON(target, 2)))
+			case "PUT /spaces/id1/environments/green":
+				assert.Equal(t, "blue", r.Header.Get("X-Contentful-Source-Environment"))
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(environmentJSON("green", EnvironmentStatusQueued, 1)))
+			case "GET /spaces/id1/environments/green":
+				_, _ = w.Write([]byte(environmentJSON("green", EnvironmentStatusReady, 1)))
+			case "PUT /spaces/id1/environments/green/content_types/ct":
+				_, _ = w.Write([]byte(readTestData(t, "content_type.json")))
+			case "PUT /spaces/id1/environment_aliases/master":
+				if r.Header.Get("X-Contentful-Version") != "2" {
+					w.WriteHeader(http.StatusConflict)
+					_, _ = w.Write([]byte(versionMismatchJSON))
+					return
+				}
+				_, _ = w.Write([]byte(environmentAliasJSON("green", 3)))
+			case "GET /spaces/id1/environments/blue":
+				_, _ = w.Write([]byte(environmentJSON("blue", EnvironmentStatusReady, 5)))
+			case "DELETE /spaces/id1/environments/blue":
+				assert.Equal(t, "5", r.Header.Get("X-Contentful-Version"))
+				w.WriteHeader(http.StatusNoContent)
+			default:
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+		}))
+		return server, &calls
+	}
+
+	opts := SwitchAliasOptions{
+		EnvironmentID: "green",
+		Migrate: func(ctx context.Context, env *EnvironmentClient) error {
+			return env.ContentTypes.Upsert(ctx, &ContentType{Sys: &Sys{ID: "ct", Version: 1}, Name: "ct"})
+		},
+		DeleteOld: true,
+	}
+
+	t.Run("retries version mismatch", func(t *testing.T) {
+		server, calls := newServer(t, "")
+		defer server.Close()
+
+		cma = NewCMA(CMAToken)
+		cma.BaseURL = server.URL
+
+		alias, err := cma.Space(spaceID).Aliases.Switch(t.Context(), "master", opts)
+		require.NoError(t, err)
+		assert.Equal(t, "green", alias.EnvironmentID())
+		assert.Equal(t, 3, alias.GetVersion())
+		assert.Equal(t, "DELETE /spaces/id1/environments/blue", (*calls)[len(*calls)-1])
+	})
+	t.Run("aborts concurrent switch", func(t *testing.T) {
+		server, calls := newServer(t, "red")
+		defer server.Close()
+
+		cma = NewCMA(CMAToken)
+		cma.BaseURL = server.URL
+
+		_, err := cma.Space(spaceID).Aliases.Switch(t.Context(), "master", opts)
+		var versionMismatchError VersionMismatchError
+		require.True(t, errors.As(err, &versionMismatchError))
+		assert.NotContains(t, *calls, "DELETE /spaces/id1/environments/blue")
+	})
+	t.Run("migration error", func(t *testing.T) {
+		server, calls := newServer(t, "")
+		defer server.Close()
+
+		cma = NewCMA(CMAToken)
+		cma.BaseURL = server.URL
+
+		failing := opts
+		failing.Migrate = func(ctx context.Context, env *EnvironmentClient) error {
+			return errors.New("boom")
+		}
+		_, err := cma.Space(spaceID).Aliases.Switch(t.Context(), "master", failing)
+		require.EqualError(t, err, `migrating environment "green": boom`)
+		assert.NotContains(t, *calls, "PUT /spaces/id1/environment_aliases/master")
+	})
+}
@@ -0,0 +1,145 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func environmentJSON(id, status string, version int) string {
+	return fmt.Sprintf(`{
+		"name": %[1]q,
+		"sys": {
+			"type": "Environment",
+			"id": %[1]q,
+			"version": %[3]d,
+			"status": {"sys": {"type": "Link", "linkType": "Status", "id": %[2]q}}
+		}
+	}`, id, status, version)
+}
+
+func TestEnvironmentsServiceList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/spaces/id1/environments", r.URL.Path)
+		_, _ = fmt.Fprintf(w, `{"sys":{"type":"Array"},"total":2,"skip":0,"limit":100,"items":[%s,%s]}`,
+			environmentJSON("master", EnvironmentStatusReady, 1),
+			environmentJSON("staging", EnvironmentStatusQueued, 1),
+		)
+	}))
+	defer server.Close()
+
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+	cma.Environment = "ignored"
+
+	col, err := cma.Environments.List(t.Context(), spaceID).Next()
+	require.NoError(t, err)
+	require.Len(t, col.Items, 2)
+	assert.Equal(t, "master", col.Items[0].Sys.ID)
+	assert.Equal(t, EnvironmentStatusReady, col.Items[0].Status())
+	assert.Equal(t, EnvironmentStatusQueued, col.Items[1].Status())
+}
+
+func TestEnvironmentsServiceCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/spaces/id1/environments/pr-42", r.URL.Path)
+		assert.Equal(t, "master", r.Header.Get("X-Contentful-Source-Environment"))
+
+		var payload map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]any{"name": "PR 42"}, payload)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(environmentJSON("pr-42", EnvironmentStatusQueued, 1)))
+	}))
+	defer server.Close()
+
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	environment := &Environment{Sys: &Sys{ID: "pr-42"}, Name: "PR 42"}
+	err := cma.Space(spaceID).Environments.Create(t.Context(), environment, "master")
+	require.NoError(t, err)
+	assert.Equal(t, EnvironmentStatusQueued, environment.Status())
+}
+
+func TestEnvironmentsServiceDelete(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/spaces/id1/environments/pr-42", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.Empty(t, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	err := cma.Environments.Delete(t.Context(), spaceID, &Environment{Sys: &Sys{ID: "pr-42", Version: 3}})
+	require.NoError(t, err)
+}
+
+func TestEnvironmentsServiceWaitReady(t *testing.T) {
+	interval := EnvironmentPollInterval
+	EnvironmentPollInterval = time.Millisecond
+	defer func() { EnvironmentPollInterval = interval }()
+
+	t.Run("ready", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := EnvironmentStatusQueued
+			if calls.Add(1) == 3 {
+				status = EnvironmentStatusReady
+			}
+			_, _ = w.Write([]byte(environmentJSON("pr-42", status, 1)))
+		}))
+		defer server.Close()
+
+		cma = NewCMA(CMAToken)
+		cma.BaseURL = server.URL
+
+		environment, err := cma.Space(spaceID).Environment("pr-42").WaitReady(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, EnvironmentStatusReady, environment.Status())
+		assert.Equal(t, int32(3), calls.Load())
+	})
+	t.Run("failed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(environmentJSON("pr-42", EnvironmentStatusFailed, 1)))
+		}))
+		defer server.Close()
+
+		cma = NewCMA(CMAToken)
+		cma.BaseURL = server.URL
+
+		_, err := cma.Environments.WaitReady(t.Context(), spaceID, "pr-42")
+		require.EqualError(t, err, `environment "pr-42" failed`)
+	})
+	t.Run("context", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(environmentJSON("pr-42", EnvironmentStatusQueued, 1)))
+		}))
+		defer server.Close()
+
+		cma = NewCMA(CMAToken)
+		cma.BaseURL = server.URL
+
+		ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := cma.Environments.WaitReady(ctx, spaceID, "pr-42")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -1,5 +1,11 @@
 package contentful
 
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
 // IncludeEntry model
 type IncludeEntry struct {
 	Fields map[string]interface{} `json:"fields,omitempty"`
@@ -18,3 +24,125 @@ type IncludeAsset struct {
 	Fields *IncludeFileFields `json:"fields"`
 	Sys    *Sys               `json:"sys"`
 }
+
+var errIncludesLocalized = errors.New("included assets are localized, use ToIncludesLocalizedAssetMap")
+
+// Includes of a collection response, decoded once. Assets of single locale
+// responses are available in both shapes, Asset holds them keyed by their
+// sys locale.
+type Includes struct {
+	Entry []*Entry
+	Asset []*Asset
+
+	includeAssets []*IncludeAsset
+	localized     bool
+
+	once    sync.Once
+	entries map[string]*Entry
+	assets  map[string]*Asset
+}
+
+// UnmarshalJSON decodes the includes
+func (inc *Includes) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Entry []*Entry `json:"Entry"`
+		Asset []struct {
+			Sys    *Sys            `json:"sys"`
+			Fields json.RawMessage `json:"fields"`
+		} `json:"Asset"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	inc.Entry = raw.Entry
+	inc.Asset = make([]*Asset, 0, len(raw.Asset))
+	for _, item := range raw.Asset {
+		asset := &Asset{Sys: item.Sys, Fields: &FileFields{}}
+		if item.Sys == nil || item.Sys.Locale == "" {
+			inc.localized = true
+			if len(item.Fields) > 0 {
+				if err := json.Unmarshal(item.Fields, asset.Fields); err != nil {
+					return err
+				}
+			}
+			inc.Asset = append(inc.Asset, asset)
+			continue
+		}
+
+		include := &IncludeAsset{Sys: item.Sys, Fields: &IncludeFileFields{}}
+		if len(item.Fields) > 0 {
+			if err := json.Unmarshal(item.Fields, include.Fields); err != nil {
+				return err
+			}
+		}
+		locale := item.Sys.Locale
+		asset.Fields.Title = map[string]string{locale: include.Fields.Title}
+		asset.Fields.Description = map[string]string{locale: include.Fields.Description}
+		asset.Fields.File = map[string]*File{locale: include.Fields.File}
+		inc.Asset = append(inc.Asset, asset)
+		inc.includeAssets = append(inc.includeAssets, include)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the includes in the shape they were received
+func (inc *Includes) MarshalJSON() ([]byte, error) {
+	assets := make([]interface{}, 0, len(inc.Asset))
+	if inc.localized || len(inc.includeAssets) != len(inc.Asset) {
+		for _, asset := range inc.Asset {
+			assets = append(assets, asset)
+		}
+	} else {
+		for _, asset := range inc.includeAssets {
+			assets = append(assets, asset)
+		}
+	}
+
+	return json.Marshal(&struct {
+		Entry []*Entry      `json:"Entry,omitempty"`
+		Asset []interface{} `json:"Asset,omitempty"`
+	}{
+		Entry: inc.Entry,
+		Asset: assets,
+	})
+}
+
+// GetEntry returns the included entry with the id or nil
+func (inc *Includes) GetEntry(id string) *Entry {
+	if inc == nil {
+		return nil
+	}
+
+	inc.index()
+	return inc.entries[id]
+}
+
+// GetAsset returns the included asset with the id or nil
+func (inc *Includes) GetAsset(id string) *Asset {
+	if inc == nil {
+		return nil
+	}
+
+	inc.index()
+	return inc.assets[id]
+}
+
+// index builds the id index on first use
+func (inc *Includes) index() {
+	inc.once.Do(func() {
+		inc.entries = make(map[string]*Entry, len(inc.Entry))
+		for _, entry := range inc.Entry {
+			if entry.Sys != nil {
+				inc.entries[entry.Sys.ID] = entry
+			}
+		}
+		inc.assets = make(map[string]*Asset, len(inc.Asset))
+		for _, asset := range inc.Asset {
+			if asset.Sys != nil {
+				inc.assets[asset.Sys.ID] = asset
+			}
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package contentful
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncludesSingleLocale(t *testing.T) {
+	data := `{"items":[],"includes":{
+		"Entry":[{"sys":{"id":"author","type":"Entry","locale":"de"},"fields":{"name":"Ada"}}],
+		"Asset":[{"sys":{"id":"photo","type":"Asset","locale":"de"},"fields":{"title":"Foto","file":{"url":"//photo.jpg"}}}]
+	}}`
+	var col Collection[Entry]
+	require.NoError(t, json.Unmarshal([]byte(data), &col))
+
+	entries, err := col.ToIncludesEntryMap()
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", entries["author"].Fields["name"])
+	assert.Same(t, entries["author"], col.Includes.GetEntry("author"))
+
+	assets, err := col.ToIncludesAssetMap()
+	require.NoError(t, err)
+	assert.Equal(t, "Foto", assets["photo"].Fields.Title)
+
+	localized, err := col.ToIncludesLocalizedAssetMap()
+	require.NoError(t, err)
+	assert.Equal(t, "Foto", localized["photo"].Fields.Title["de"])
+	assert.Equal(t, "//photo.jpg", col.Includes.GetAsset("photo").Fields.File["de"].URL)
+
+	encoded, err := json.Marshal(col.Includes)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"Entry":[{"sys":{"id":"author","type":"Entry","locale":"de"},"fields":{"name":"Ada"}}],
+		"Asset":[{"sys":{"id":"photo","type":"Asset","locale":"de"},"fields":{"title":"Foto","file":{"url":"//photo.jpg"}}}]
+	}`, string(encoded))
+}
+
+func TestIncludesLocalized(t *testing.T) {
+	data := `{"items":[],"includes":{
+		"Asset":[{"sys":{"id":"photo","type":"Asset"},"fields":{"title":{"de":"Foto","en":"Photo"}}}]
+	}}`
+	var col Collection[Entry]
+	require.NoError(t, json.Unmarshal([]byte(data), &col))
+
+	_, err := col.ToIncludesAsset()
+	require.ErrorIs(t, err, errIncludesLocalized)
+
+	assets, err := col.ToIncludesLocalizedAssetMap()
+	require.NoError(t, err)
+	assert.Equal(t, "Photo", assets["photo"].Fields.Title["en"])
+	assert.Nil(t, col.Includes.GetEntry("photo"))
+}
+
+func TestIncludesEmpty(t *testing.T) {
+	var col Collection[Entry]
+	require.NoError(t, json.Unmarshal([]byte(`{"items":[]}`), &col))
+
+	entries, err := col.ToIncludesEntryMap()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assets, err := col.ToIncludesAsset()
+	require.NoError(t, err)
+	assert.Empty(t, assets)
+	assert.Nil(t, col.Includes.GetAsset("photo"))
+}
@@ -0,0 +1,431 @@
+// Package cmafake provides an in memory space of the management api with the
+// master environment, shared by the tests of the transfer and migration
+// packages.
+package cmafake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/foomo/contentful"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// Prefix of the space paths, it is not part of the recorded requests
+	Prefix = "/spaces/space"
+	// EnvPrefix of the master environment paths
+	EnvPrefix = Prefix + "/environments/master"
+)
+
+// sysTypes are the sys types of created items by their key
+var sysTypes = map[string]string{
+	"content_types": "ContentType",
+	"entries":       "Entry",
+	"assets":        "Asset",
+	"locales":       "Locale",
+	"tags":          "Tag",
+}
+
+// Space is an in memory space of the management api. It checks versions,
+// links of published entries and the omission of deleted content type
+// fields.
+type Space struct {
+	mu sync.Mutex
+	// Items are stored by the last segment of their list path
+	Items map[string][]map[string]interface{}
+	// Published holds the published content types by id
+	Published map[string]map[string]interface{}
+	// Uploads holds the uploaded files by upload id
+	Uploads map[string]string
+	// Requests are the received requests as "METHOD path?query", without
+	// the space prefix
+	Requests []string
+	// Fail makes the next write of the entity with the id fail
+	Fail map[string]bool
+}
+
+// New returns a space holding the items of the export data and a client of
+// the space talking to it
+func New(t *testing.T, data string) (*Space, *contentful.SpaceClient) {
+	t.Helper()
+
+	f := &Space{
+		Items:     map[string][]map[string]interface{}{},
+		Published: map[string]map[string]interface{}{},
+		Uploads:   map[string]string{},
+		Fail:      map[string]bool{},
+	}
+	require.NoError(t, json.Unmarshal([]byte(data), &f.Items))
+	for _, ct := range f.Items["content_types"] {
+		if Sys(ct)["publishedVersion"] != nil {
+			f.Published[ID(ct)] = clone(ct)
+		}
+	}
+
+	mux := http.NewServeMux()
+	for _, path := range []string{
+		EnvPrefix + "/content_types",
+		EnvPrefix + "/editor_interfaces",
+		EnvPrefix + "/entries",
+		EnvPrefix + "/assets",
+		EnvPrefix + "/locales",
+		EnvPrefix + "/tags",
+		Prefix + "/webhook_definitions",
+		Prefix + "/roles",
+	} {
+		key := path[strings.LastIndex(path, "/")+1:]
+		mux.HandleFunc("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+			f.list(w, r, key)
+		})
+		mux.HandleFunc("GET "+path+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+			item := f.Find(key, r.PathValue("id"))
+			if item == nil {
+				f.error(w, http.StatusNotFound, "NotFound")
+				return
+			}
+			f.write(w, item)
+		})
+		mux.HandleFunc("PUT "+path+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+			f.put(w, r, key, r.PathValue("id"))
+		})
+		mux.HandleFunc("PUT "+path+"/{id}/published", func(w http.ResponseWriter, r *http.Request) {
+			f.publish(w, r, key, r.PathValue("id"))
+		})
+	}
+	mux.HandleFunc("POST "+EnvPrefix+"/locales", func(w http.ResponseWriter, r *http.Request) {
+		f.put(w, r, "locales", fmt.Sprintf("locale-%d", len(f.Items["locales"])))
+	})
+	mux.HandleFunc("DELETE "+EnvPrefix+"/content_types/{id}/published", func(w http.ResponseWriter, r *http.Request) {
+		ct := f.Find("content_types", r.PathValue("id"))
+		if ct == nil {
+			f.error(w, http.StatusNotFound, "NotFound")
+			return
+		}
+		if !f.checkVersion(w, r, ct) {
+			return
+		}
+		delete(Sys(ct), "publishedVersion")
+		Sys(ct)["version"] = Version(ct) + 1
+		delete(f.Published, r.PathValue("id"))
+		f.write(w, ct)
+	})
+	mux.HandleFunc("DELETE "+EnvPrefix+"/content_types/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		ct := f.Find("content_types", id)
+		if ct == nil {
+			f.error(w, http.StatusNotFound, "NotFound")
+			return
+		}
+		if _, ok := f.Published[id]; ok {
+			f.error(w, http.StatusBadRequest, "BadRequest")
+			return
+		}
+		if !f.checkVersion(w, r, ct) {
+			return
+		}
+		f.remove("content_types", id)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("GET "+EnvPrefix+"/content_types/{id}/editor_interface", func(w http.ResponseWriter, r *http.Request) {
+		f.write(w, f.editorInterface(r.PathValue("id")))
+	})
+	mux.HandleFunc("PUT "+EnvPrefix+"/content_types/{id}/editor_interface", func(w http.ResponseWriter, r *http.Request) {
+		current := f.editorInterface(r.PathValue("id"))
+		if !f.checkVersion(w, r, current) {
+			return
+		}
+		var ei map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&ei)
+		for key, value := range ei {
+			if key != "sys" {
+				current[key] = value
+			}
+		}
+		Sys(current)["version"] = Version(current) + 1
+		f.write(w, current)
+	})
+	mux.HandleFunc("PUT "+EnvPrefix+"/assets/{id}/files/{locale}/process", func(w http.ResponseWriter, r *http.Request) {
+		asset := f.Find("assets", r.PathValue("id"))
+		if !f.checkVersion(w, r, asset) {
+			return
+		}
+		file := asset["fields"].(map[string]interface{})["file"].(map[string]interface{})[r.PathValue("locale")].(map[string]interface{})
+		source, _ := file["upload"].(string)
+		if from, ok := file["uploadFrom"].(map[string]interface{}); ok {
+			source = f.Uploads[ID(from)]
+		}
+		file["url"] = "//assets.example/" + r.PathValue("id") + "/" + file["fileName"].(string)
+		file["details"] = map[string]interface{}{"size": float64(len(source))}
+		delete(file, "upload")
+		delete(file, "uploadFrom")
+		Sys(asset)["version"] = Version(asset) + 1
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST "+EnvPrefix+"/uploads", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		id := fmt.Sprintf("upload-%d", len(f.Uploads))
+		f.Uploads[id] = string(data)
+		f.write(w, map[string]interface{}{"sys": map[string]interface{}{"id": id, "type": "Upload"}})
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		request := r.Method + " " + strings.TrimPrefix(r.URL.Path, Prefix)
+		if r.URL.RawQuery != "" {
+			request += "?" + r.URL.RawQuery
+		}
+		f.Requests = append(f.Requests, request)
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	cma := contentful.NewCMA("token").SetRetryPolicy(contentful.NoRetryPolicy())
+	cma.BaseURL = server.URL
+	cma.UploadURL = server.URL
+
+	return f, cma.Space("space")
+}
+
+// Find returns the item of the key with the id
+func (f *Space) Find(key, id string) map[string]interface{} {
+	for _, item := range f.Items[key] {
+		if ID(item) == id {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// list writes a page of the items, filtered by sys.id[in],
+// sys.contentType.sys.id[in] and content_type
+func (f *Space) list(w http.ResponseWriter, r *http.Request, key string) {
+	query := r.URL.Query()
+	items := []interface{}{}
+	for _, item := range f.Items[key] {
+		if !matchIn(query.Get("sys.id[in]"), ID(item)) ||
+			!matchIn(query.Get("sys.contentType.sys.id[in]"), ContentTypeID(item)) ||
+			!matchIn(query.Get("content_type"), ContentTypeID(item)) {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	total := len(items)
+	skip, _ := strconv.Atoi(query.Get("skip"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+	items = items[min(skip, total):]
+	if limit > 0 {
+		items = items[:min(limit, len(items))]
+	}
+
+	f.write(w, map[string]interface{}{"total": total, "skip": skip, "limit": limit, "items": items})
+}
+
+// put creates or updates an item, checking the version of existing items
+func (f *Space) put(w http.ResponseWriter, r *http.Request, key, id string) {
+	if f.Fail[id] {
+		delete(f.Fail, id)
+		f.error(w, http.StatusUnprocessableEntity, "ValidationFailed")
+		return
+	}
+
+	current := f.Find(key, id)
+	if current != nil && !f.checkVersion(w, r, current) {
+		return
+	}
+
+	var item map[string]interface{}
+	_ = json.NewDecoder(r.Body).Decode(&item)
+	if key == "content_types" && !f.checkFields(w, id, item) {
+		return
+	}
+
+	itemSys := map[string]interface{}{"id": id, "version": float64(1), "createdAt": "2024-06-01T00:00:00Z"}
+	if sysType, ok := sysTypes[key]; ok {
+		itemSys["type"] = sysType
+	}
+	if visibility, ok := Sys(item)["visibility"]; ok {
+		itemSys["visibility"] = visibility
+	}
+	if ct := r.Header.Get("X-Contentful-Content-Type"); ct != "" {
+		itemSys["contentType"] = map[string]interface{}{"sys": map[string]interface{}{"id": ct, "type": "Link", "linkType": "ContentType"}}
+	}
+	if current != nil {
+		itemSys = Sys(current)
+		itemSys["version"] = Version(current) + 1
+		f.remove(key, id)
+	}
+	item["sys"] = itemSys
+	f.Items[key] = append(f.Items[key], item)
+
+	f.write(w, item)
+}
+
+// checkFields renames the fields with a new id and fails if a published
+// field is removed without being omitted first
+func (f *Space) checkFields(w http.ResponseWriter, id string, ct map[string]interface{}) bool {
+	fields, _ := ct["fields"].([]interface{})
+	ids := map[string]bool{}
+	for _, field := range fields {
+		field := field.(map[string]interface{})
+		if newID, ok := field["newId"]; ok {
+			field["id"] = newID
+			delete(field, "newId")
+		}
+		ids[field["id"].(string)] = true
+	}
+
+	published, _ := f.Published[id]["fields"].([]interface{})
+	for _, field := range published {
+		field := field.(map[string]interface{})
+		if !ids[field["id"].(string)] && field["omitted"] != true {
+			f.error(w, http.StatusUnprocessableEntity, "ValidationFailed")
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *Space) publish(w http.ResponseWriter, r *http.Request, key, id string) {
+	item := f.Find(key, id)
+	if item == nil {
+		f.error(w, http.StatusNotFound, "NotFound")
+		return
+	}
+	if !f.checkVersion(w, r, item) {
+		return
+	}
+	for _, link := range links(item["fields"]) {
+		if linked := f.Find("entries", link); linked == nil || Sys(linked)["publishedVersion"] == nil {
+			f.error(w, http.StatusUnprocessableEntity, "notResolvable")
+			return
+		}
+	}
+
+	Sys(item)["publishedVersion"] = Version(item)
+	Sys(item)["version"] = Version(item) + 1
+	if key == "content_types" {
+		f.Published[id] = clone(item)
+	}
+	f.write(w, item)
+}
+
+// editorInterface returns the editor interface of the content type,
+// creating the default one
+func (f *Space) editorInterface(contentTypeID string) map[string]interface{} {
+	for _, item := range f.Items["editor_interfaces"] {
+		if contentTypeID == ContentTypeID(item) {
+			return item
+		}
+	}
+
+	item := map[string]interface{}{
+		"sys": map[string]interface{}{
+			"id": "default", "version": float64(1),
+			"contentType": map[string]interface{}{"sys": map[string]interface{}{"id": contentTypeID, "type": "Link", "linkType": "ContentType"}},
+		},
+		"controls": []interface{}{},
+	}
+	f.Items["editor_interfaces"] = append(f.Items["editor_interfaces"], item)
+
+	return item
+}
+
+func (f *Space) remove(key, id string) {
+	f.Items[key] = slices.DeleteFunc(f.Items[key], func(item map[string]interface{}) bool { return ID(item) == id })
+}
+
+func (f *Space) checkVersion(w http.ResponseWriter, r *http.Request, current map[string]interface{}) bool {
+	if r.Header.Get("X-Contentful-Version") != strconv.Itoa(int(Version(current))) {
+		f.error(w, http.StatusConflict, "VersionMismatch")
+		return false
+	}
+
+	return true
+}
+
+func (f *Space) write(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (f *Space) error(w http.ResponseWriter, status int, id string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"sys": map[string]interface{}{"type": "Error", "id": id}, "message": id})
+}
+
+// Sys returns the sys of the item, an empty one if it has none
+func Sys(item map[string]interface{}) map[string]interface{} {
+	s, _ := item["sys"].(map[string]interface{})
+	if s == nil {
+		s = map[string]interface{}{}
+	}
+
+	return s
+}
+
+// ID returns the sys.id of the item
+func ID(item map[string]interface{}) string {
+	id, _ := Sys(item)["id"].(string)
+
+	return id
+}
+
+// Version returns the sys.version of the item
+func Version(item map[string]interface{}) float64 {
+	v, _ := Sys(item)["version"].(float64)
+
+	return v
+}
+
+// ContentTypeID returns the id of the content type the item links to
+func ContentTypeID(item map[string]interface{}) string {
+	ct, _ := Sys(item)["contentType"].(map[string]interface{})
+
+	return ID(ct)
+}
+
+func matchIn(values, value string) bool {
+	return values == "" || slices.Contains(strings.Split(values, ","), value)
+}
+
+// links returns the ids of the entries linked in the value
+func links(v interface{}) []string {
+	var ids []string
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if s := Sys(v); s["type"] == "Link" && s["linkType"] == "Entry" {
+			ids = append(ids, ID(v))
+		}
+		for _, value := range v {
+			ids = append(ids, links(value)...)
+		}
+	case []interface{}:
+		for _, value := range v {
+			ids = append(ids, links(value)...)
+		}
+	}
+
+	return ids
+}
+
+func clone(item map[string]interface{}) map[string]interface{} {
+	data, _ := json.Marshal(item)
+	var c map[string]interface{}
+	_ = json.Unmarshal(data, &c)
+
+	return c
+}
@@ -52,6 +52,7 @@ func (service *LocalesService) List(ctx context.Context, spaceID string) *Collec
 	path := fmt.Sprintf("/spaces/%s%s/locales", spaceID, getEnvPath(service.c))
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Locales", "List", spaceID, "")
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return &Collection[Locale]{}
@@ -69,6 +70,7 @@ func (service *LocalesService) Get(ctx context.Context, spaceID, localeID string
 	path := fmt.Sprintf("/spaces/%s%s/locales/%s", spaceID, getEnvPath(service.c), localeID)
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Locales", "Get", spaceID, localeID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return nil, err
@@ -87,6 +89,7 @@ func (service *LocalesService) Delete(ctx context.Context, spaceID string, local
 	path := fmt.Sprintf("/spaces/%s%s/locales/%s", spaceID, getEnvPath(service.c), locale.Sys.ID)
 	method := http.MethodDelete
 
+	ctx = service.c.operation(ctx, "Locales", "Delete", spaceID, locale.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -116,6 +119,7 @@ func (service *LocalesService) Upsert(ctx context.Context, spaceID string, local
 		method = http.MethodPost
 	}
 
+	ctx = service.c.operation(ctx, "Locales", "Upsert", spaceID, sysID(locale.Sys))
 	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
 	if err != nil {
 		return err
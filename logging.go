@@ -0,0 +1,137 @@
+package contentful
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/aoliveti/curling"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	debugLogger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	secretBodyRegex = regexp.MustCompile(`("httpBasicPassword"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// SetLogger enables structured logging of all requests. Request and response
+// bodies are additionally logged on debug level when Debug is set.
+func (c *Contentful) SetLogger(logger *slog.Logger) *Contentful {
+	c.logger = logger
+	return c
+}
+
+// log returns the configured logger, falling back to a stdout debug logger
+// in Debug mode
+func (c *Contentful) log() *slog.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	if c.Debug {
+		return debugLogger
+	}
+	return nil
+}
+
+func (c *Contentful) logBodies(ctx context.Context, logger *slog.Logger) bool {
+	return c.Debug && logger.Enabled(ctx, slog.LevelDebug)
+}
+
+// logRequest logs the outgoing request as redacted curl command
+func (c *Contentful) logRequest(req *http.Request, attempt int) {
+	logger := c.log()
+	if logger == nil || !c.logBodies(req.Context(), logger) {
+		return
+	}
+
+	clone := req.Clone(req.Context())
+	if clone.Header.Get("Authorization") != "" {
+		clone.Header.Set("Authorization", redacted)
+	}
+	clone.Body = nil
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			clone.Body = io.NopCloser(bytes.NewReader(redactBody(data)))
+		}
+	}
+
+	attrs := append(requestAttrs(req, attempt), slog.Bool("body_omitted", req.Body != nil && req.GetBody == nil))
+	if cmd, err := curling.NewFromRequest(clone); err == nil {
+		attrs = append(attrs, slog.String("curl", cmd.String()))
+	}
+
+	logger.LogAttrs(req.Context(), slog.LevelDebug, "contentful request", attrs...)
+}
+
+// logResponse logs the outcome of a single attempt. The response body is
+// buffered and replaced when it is dumped.
+func (c *Contentful) logResponse(req *http.Request, res *http.Response, err error, attempt int, duration time.Duration) {
+	logger := c.log()
+	if logger == nil {
+		return
+	}
+
+	attrs := append(requestAttrs(req, attempt), slog.Duration("duration", duration))
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		logger.LogAttrs(req.Context(), slog.LevelWarn, "contentful request failed", attrs...)
+		return
+	}
+
+	attrs = append(attrs,
+		slog.Int("status", res.StatusCode),
+		slog.String("request_id", res.Header.Get("X-Contentful-Request-Id")),
+	)
+	for _, header := range []struct{ key, name string }{
+		{"X-Contentful-Ratelimit-Second-Remaining", "ratelimit_second_remaining"},
+		{"X-Contentful-Ratelimit-Hour-Remaining", "ratelimit_hour_remaining"},
+		{"X-Contentful-Ratelimit-Reset", "ratelimit_reset"},
+	} {
+		if value := res.Header.Get(header.key); value != "" {
+			attrs = append(attrs, slog.String(header.name, value))
+		}
+	}
+
+	level := slog.LevelInfo
+	if res.StatusCode >= http.StatusBadRequest {
+		level = slog.LevelWarn
+	}
+	logger.LogAttrs(req.Context(), level, "contentful response", attrs...)
+
+	if c.logBodies(req.Context(), logger) && res.Body != nil {
+		data, errRead := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		res.Body = io.NopCloser(bytes.NewReader(data))
+		if errRead == nil {
+			logger.LogAttrs(req.Context(), slog.LevelDebug, "contentful response body",
+				slog.String("request_id", res.Header.Get("X-Contentful-Request-Id")),
+				slog.String("body", string(redactBody(data))),
+			)
+		}
+	}
+}
+
+func requestAttrs(req *http.Request, attempt int) []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("retry", attempt-1),
+	}
+	if op, ok := OperationFromContext(req.Context()); ok {
+		attrs = append(attrs, slog.String("operation", op.String()))
+	}
+	return attrs
+}
+
+// redactBody masks secrets such as webhook basic auth passwords
+func redactBody(data []byte) []byte {
+	return secretBodyRegex.ReplaceAll(data, []byte(`${1}"`+redacted+`"`))
+}
@@ -0,0 +1,93 @@
+package contentful
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLoggerResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Contentful-Request-Id", "request-id")
+		w.Header().Set("X-Contentful-Ratelimit-Second-Remaining", "6")
+		_, _ = w.Write([]byte(readTestData(t, "space-1.json")))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	cma := NewCMA(CMAToken).SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	cma.BaseURL = server.URL
+
+	_, err := cma.Spaces.Get(t.Context(), "id1")
+	require.NoError(t, err)
+
+	records := logRecords(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "contentful response", records[0]["msg"])
+	assert.Equal(t, http.MethodGet, records[0]["method"])
+	assert.Equal(t, "/spaces/id1", records[0]["path"])
+	assert.InDelta(t, 200, records[0]["status"], 0)
+	assert.InDelta(t, 0, records[0]["retry"], 0)
+	assert.Equal(t, "request-id", records[0]["request_id"])
+	assert.Equal(t, "6", records[0]["ratelimit_second_remaining"])
+	assert.Equal(t, "Spaces.Get", records[0]["operation"])
+	assert.Contains(t, records[0], "duration")
+}
+
+func TestLoggerDebugRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(readTestData(t, "webhook.json")))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	cma := NewCMA(CMAToken).SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	cma.BaseURL = server.URL
+	cma.Debug = true
+
+	webhook := &Webhook{
+		Name:              "webhook",
+		URL:               "https://www.example.com/test",
+		HTTPBasicUsername: "username",
+		HTTPBasicPassword: "s3cr3t",
+	}
+	require.NoError(t, cma.Webhooks.Upsert(t.Context(), spaceID, webhook))
+
+	output := buf.String()
+	assert.NotContains(t, output, CMAToken)
+	assert.NotContains(t, output, "s3cr3t")
+	assert.Contains(t, output, redacted)
+
+	records := logRecords(t, &buf)
+	require.Len(t, records, 3)
+	assert.Equal(t, "contentful request", records[0]["msg"])
+	assert.Contains(t, records[0]["curl"], "curl")
+	assert.Equal(t, "contentful response", records[1]["msg"])
+	assert.Equal(t, "contentful response body", records[2]["msg"])
+	assert.Equal(t, "webhook-name", webhook.Name)
+}
+
+func TestRedactBody(t *testing.T) {
+	assert.JSONEq(t,
+		`{"httpBasicUsername":"user","httpBasicPassword":"[REDACTED]"}`,
+		string(redactBody([]byte(`{"httpBasicUsername":"user","httpBasicPassword":"pa\"ss"}`))),
+	)
+}
@@ -0,0 +1,566 @@
+package contentful
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMappingDepth is the number of nested entry levels DecodeEntry
+// decodes if MappingOptions.Depth is not set
+const DefaultMappingDepth = 10
+
+// MappingOptions configures DecodeEntry and EncodeEntry.
+//
+// Struct fields are mapped with the contentful tag, e.g.
+//
+//	type Cat struct {
+//		Sys        *contentful.Sys
+//		Name       string                     `contentful:"name"`
+//		Names      map[string]string          `contentful:"name,localized"`
+//		Birthday   time.Time                  `contentful:"birthday"`
+//		Location   *contentful.Location       `contentful:"location"`
+//		BestFriend *Cat                       `contentful:"bestFriend"`
+//		Image      *contentful.Asset          `contentful:"image"`
+//		Tags       []string                   `contentful:"tags,omitempty"`
+//	}
+//
+// A field of type *Sys receives the sys of the entry. Fields with the
+// localized option must be maps by locale, other fields hold the value of
+// the selected locale.
+type MappingOptions struct {
+	// Locale selects the value of fields without the localized option. It
+	// can be omitted when decoding entries of a single locale.
+	Locale string
+	// Resolver resolves links to entries and assets. Links already resolved
+	// with ResolveLinks are decoded without it.
+	Resolver *LinkResolver
+	// Depth limits the levels of nested entries, defaults to DefaultMappingDepth
+	Depth int
+	// ContentType is set by EncodeEntry if the sys has no content type
+	ContentType string
+}
+
+type mappedField struct {
+	index     []int
+	id        string
+	localized bool
+	omitempty bool
+}
+
+type mappedStruct struct {
+	sys    []int
+	fields []mappedField
+}
+
+var (
+	mappedStructs sync.Map
+
+	sysPtrType   = reflect.TypeFor[*Sys]()
+	entryPtrType = reflect.TypeFor[*Entry]()
+	assetPtrType = reflect.TypeFor[*Asset]()
+	linkPtrType  = reflect.TypeFor[*Link]()
+	timeType     = reflect.TypeFor[time.Time]()
+)
+
+// DecodeEntry decodes the fields of the entry into v, a pointer to a struct
+// with contentful tags. Links are decoded into nested structs if they are
+// resolved, links back to an entry being decoded are left empty.
+func DecodeEntry(entry *Entry, v any, opts *MappingOptions) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decoding an entry requires a pointer to a struct, got %T", v)
+	}
+
+	d := &mapper{opts: mappingOptions(opts)}
+	return d.decodeEntry(entry, rv.Elem(), d.opts.Depth, nil)
+}
+
+// EncodeEntry encodes v, a pointer to a struct with contentful tags, into an
+// entry with localized fields as required by EntriesService.Upsert. Nested
+// entries and assets are encoded as links.
+func EncodeEntry(v any, opts *MappingOptions) (*Entry, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("encoding an entry requires a struct, got %T", v)
+	}
+
+	e := &mapper{opts: mappingOptions(opts)}
+	return e.encodeEntry(rv)
+}
+
+func mappingOptions(opts *MappingOptions) MappingOptions {
+	var o MappingOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Depth == 0 {
+		o.Depth = DefaultMappingDepth
+	}
+
+	return o
+}
+
+// mapStruct returns the cached mapping of the struct type
+func mapStruct(t reflect.Type) *mappedStruct {
+	if m, ok := mappedStructs.Load(t); ok {
+		return m.(*mappedStruct)
+	}
+
+	m := &mappedStruct{}
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		tag, ok := field.Tag.Lookup("contentful")
+		if !ok {
+			if field.Type == sysPtrType && m.sys == nil {
+				m.sys = field.Index
+			}
+			continue
+		}
+
+		id, options, _ := strings.Cut(tag, ",")
+		if id == "-" || id == "" {
+			continue
+		}
+		m.fields = append(m.fields, mappedField{
+			index:     field.Index,
+			id:        id,
+			localized: slices.Contains(strings.Split(options, ","), "localized"),
+			omitempty: slices.Contains(strings.Split(options, ","), "omitempty"),
+		})
+	}
+
+	actual, _ := mappedStructs.LoadOrStore(t, m)
+	return actual.(*mappedStruct)
+}
+
+// isMapped reports whether the struct type maps an entry
+func isMapped(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t == timeType {
+		return false
+	}
+	m := mapStruct(t)
+
+	return m.sys != nil || len(m.fields) > 0
+}
+
+type mapper struct {
+	opts MappingOptions
+}
+
+func (d *mapper) decodeEntry(entry *Entry, rv reflect.Value, depth int, path []string) error {
+	m := mapStruct(rv.Type())
+	if m.sys != nil {
+		rv.FieldByIndex(m.sys).Set(reflect.ValueOf(entry.Sys))
+	}
+
+	// entries of a single locale have the sys locale set
+	single := entry.Sys != nil && entry.Sys.Locale != ""
+	if entry.Sys != nil {
+		path = append(path, entry.Sys.ID)
+	}
+
+	for _, f := range m.fields {
+		raw, ok := entry.Fields[f.id]
+		if !ok {
+			continue
+		}
+		target := rv.FieldByIndex(f.index)
+
+		if f.localized {
+			if err := d.decodeLocalized(raw, target, single, entry.Sys, depth, path); err != nil {
+				return fmt.Errorf("field %q: %w", f.id, err)
+			}
+			continue
+		}
+
+		value := raw
+		if !single {
+			values, ok := raw.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("field %q: expected values by locale, got %T", f.id, raw)
+			}
+			if value, ok = d.localeValue(values); !ok {
+				if d.opts.Locale == "" {
+					return fmt.Errorf("field %q: a locale is required to select one of %d values", f.id, len(values))
+				}
+				continue
+			}
+		}
+
+		if err := d.decodeValue(value, target, depth, path); err != nil {
+			return fmt.Errorf("field %q: %w", f.id, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *mapper) decodeLocalized(raw interface{}, target reflect.Value, single bool, sys *Sys, depth int, path []string) error {
+	t := target.Type()
+	if t.Kind() != reflect.Map || t.Key().Kind() != reflect.String {
+		return fmt.Errorf("localized fields require a map by locale, got %s", t)
+	}
+
+	values, ok := raw.(map[string]interface{})
+	if single {
+		values = map[string]interface{}{sys.Locale: raw}
+	} else if !ok {
+		return fmt.Errorf("expected values by locale, got %T", raw)
+	}
+
+	result := reflect.MakeMapWithSize(t, len(values))
+	for locale, value := range values {
+		elem := reflect.New(t.Elem()).Elem()
+		if err := d.decodeValue(value, elem, depth, path); err != nil {
+			return fmt.Errorf("locale %q: %w", locale, err)
+		}
+		result.SetMapIndex(reflect.ValueOf(locale).Convert(t.Key()), elem)
+	}
+	target.Set(result)
+
+	return nil
+}
+
+// localeValue selects the value of the configured locale, or the only value
+func (d *mapper) localeValue(values map[string]interface{}) (interface{}, bool) {
+	if d.opts.Locale != "" {
+		value, ok := values[d.opts.Locale]
+		return value, ok
+	}
+	if len(values) == 1 {
+		for _, value := range values {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+func (d *mapper) decodeValue(value interface{}, target reflect.Value, depth int, path []string) error {
+	if value == nil {
+		return nil
+	}
+
+	t := target.Type()
+	switch t {
+	case timeType:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a date string, got %T", value)
+		}
+		date, err := parseDate(s)
+		if err != nil {
+			return err
+		}
+		target.Set(reflect.ValueOf(date))
+		return nil
+	case entryPtrType:
+		if entry := d.linkedEntry(value); entry != nil {
+			target.Set(reflect.ValueOf(entry))
+		}
+		return nil
+	case assetPtrType:
+		if asset := d.linkedAsset(value); asset != nil {
+			target.Set(reflect.ValueOf(asset))
+		}
+		return nil
+	case linkPtrType:
+		if link := linkTo(value); link != nil {
+			target.Set(reflect.ValueOf(link))
+		}
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		if isMapped(t.Elem()) {
+			entry := d.linkedEntry(value)
+			if entry == nil || depth <= 0 || (entry.Sys != nil && slices.Contains(path, entry.Sys.ID)) {
+				return nil
+			}
+			elem := reflect.New(t.Elem())
+			if err := d.decodeEntry(entry, elem.Elem(), depth-1, path); err != nil {
+				return err
+			}
+			target.Set(elem)
+			return nil
+		}
+		elem := reflect.New(t.Elem())
+		if err := d.decodeValue(value, elem.Elem(), depth, path); err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+	case reflect.Struct:
+		if isMapped(t) {
+			entry := d.linkedEntry(value)
+			if entry == nil || depth <= 0 || (entry.Sys != nil && slices.Contains(path, entry.Sys.ID)) {
+				return nil
+			}
+			return d.decodeEntry(entry, target, depth-1, path)
+		}
+	case reflect.Slice:
+		if items, ok := value.([]interface{}); ok {
+			result := reflect.MakeSlice(t, 0, len(items))
+			for _, item := range items {
+				elem := reflect.New(t.Elem()).Elem()
+				if err := d.decodeValue(item, elem, depth, path); err != nil {
+					return err
+				}
+				// unresolved links are skipped
+				if (elem.Kind() == reflect.Pointer && elem.IsNil()) && item != nil {
+					continue
+				}
+				result = reflect.Append(result, elem)
+			}
+			target.Set(result)
+			return nil
+		}
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(t) {
+		target.Set(rv)
+		return nil
+	}
+	if convertible(rv.Type(), t) {
+		converted := rv.Convert(t)
+		// integers must hold the number, 1.5 is not truncated to 1
+		if t.Kind() >= reflect.Int && t.Kind() <= reflect.Uintptr && converted.Convert(rv.Type()).Interface() != rv.Interface() {
+			return fmt.Errorf("%v does not fit into %s", value, t)
+		}
+		target.Set(converted)
+		return nil
+	}
+
+	// objects like locations and rich text documents, e.g. *richtext.Node
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, target.Addr().Interface())
+}
+
+// linkedEntry returns the entry of a resolved link or resolves the link
+func (d *mapper) linkedEntry(value interface{}) *Entry {
+	switch v := value.(type) {
+	case *Entry:
+		return v
+	case map[string]interface{}:
+		if link, ok := linkOf(v); ok && link.Sys.LinkType == "Entry" && d.opts.Resolver != nil {
+			return d.opts.Resolver.entries[link.Sys.ID]
+		}
+	}
+
+	return nil
+}
+
+// linkedAsset returns the asset of a resolved link or resolves the link
+func (d *mapper) linkedAsset(value interface{}) *Asset {
+	switch v := value.(type) {
+	case *Asset:
+		return v
+	case map[string]interface{}:
+		if link, ok := linkOf(v); ok && link.Sys.LinkType == "Asset" && d.opts.Resolver != nil {
+			return d.opts.Resolver.assets[link.Sys.ID]
+		}
+	}
+
+	return nil
+}
+
+func (e *mapper) encodeEntry(rv reflect.Value) (*Entry, error) {
+	m := mapStruct(rv.Type())
+
+	entry := &Entry{
+		Sys:    &Sys{},
+		Fields: make(map[string]interface{}, len(m.fields)),
+	}
+	if m.sys != nil {
+		if sys, _ := rv.FieldByIndex(m.sys).Interface().(*Sys); sys != nil {
+			copied := *sys
+			entry.Sys = &copied
+		}
+	}
+	if entry.Sys.ContentType == nil && e.opts.ContentType != "" {
+		entry.Sys.ContentType = &ContentType{Sys: NewLink("ContentType", e.opts.ContentType).Sys}
+	}
+
+	for _, f := range m.fields {
+		fv := rv.FieldByIndex(f.index)
+		if isNil(fv) || (f.omitempty && fv.IsZero()) {
+			continue
+		}
+
+		if f.localized {
+			if fv.Kind() != reflect.Map || fv.Type().Key().Kind() != reflect.String {
+				return nil, fmt.Errorf("field %q: localized fields require a map by locale, got %s", f.id, fv.Type())
+			}
+			values := localizedValues(entry.Fields, f.id)
+			for iter := fv.MapRange(); iter.Next(); {
+				value, err := e.encodeValue(iter.Value())
+				if err != nil {
+					return nil, fmt.Errorf("field %q: %w", f.id, err)
+				}
+				values[iter.Key().String()] = value
+			}
+			continue
+		}
+
+		if e.opts.Locale == "" {
+			return nil, errors.New("encoding an entry requires a locale")
+		}
+		value, err := e.encodeValue(fv)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", f.id, err)
+		}
+		localizedValues(entry.Fields, f.id)[e.opts.Locale] = value
+	}
+
+	return entry, nil
+}
+
+// localizedValues returns the values by locale of the field, struct fields
+// mapping the same field id are merged
+func localizedValues(fields map[string]interface{}, id string) map[string]interface{} {
+	values, ok := fields[id].(map[string]interface{})
+	if !ok {
+		values = map[string]interface{}{}
+		fields[id] = values
+	}
+
+	return values
+}
+
+func (e *mapper) encodeValue(fv reflect.Value) (interface{}, error) {
+	if isNil(fv) {
+		return nil, nil
+	}
+
+	switch fv.Type() {
+	case timeType:
+		return fv.Interface().(time.Time).Format(time.RFC3339), nil
+	case entryPtrType:
+		return linkTo(fv.Interface()), nil
+	case assetPtrType:
+		return linkTo(fv.Interface()), nil
+	case linkPtrType:
+		return fv.Interface(), nil
+	}
+
+	switch fv.Kind() {
+	case reflect.Pointer:
+		if isMapped(fv.Type().Elem()) {
+			return e.encodeLink(fv.Elem())
+		}
+		return e.encodeValue(fv.Elem())
+	case reflect.Struct:
+		if isMapped(fv.Type()) {
+			return e.encodeLink(fv)
+		}
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		items := make([]interface{}, 0, fv.Len())
+		for i := range fv.Len() {
+			item, err := e.encodeValue(fv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	return fv.Interface(), nil
+}
+
+// encodeLink encodes a nested entry as link
+func (e *mapper) encodeLink(rv reflect.Value) (interface{}, error) {
+	m := mapStruct(rv.Type())
+	if m.sys == nil {
+		return nil, fmt.Errorf("linking %s requires a sys field", rv.Type())
+	}
+	sys, _ := rv.FieldByIndex(m.sys).Interface().(*Sys)
+	if sys == nil || sys.ID == "" {
+		return nil, fmt.Errorf("linking %s requires a sys id", rv.Type())
+	}
+
+	return NewLink("Entry", sys.ID), nil
+}
+
+// linkTo returns a link to the linked or resolved value
+func linkTo(value interface{}) *Link {
+	switch v := value.(type) {
+	case *Link:
+		return v
+	case *Entry:
+		if v != nil && v.Sys != nil {
+			return NewLink("Entry", v.Sys.ID)
+		}
+	case *Asset:
+		if v != nil && v.Sys != nil {
+			return NewLink("Asset", v.Sys.ID)
+		}
+	case map[string]interface{}:
+		if link, ok := linkOf(v); ok {
+			return link
+		}
+	}
+
+	return nil
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// convertible reports whether a decoded json value converts to the type
+func convertible(from, to reflect.Type) bool {
+	if !from.ConvertibleTo(to) {
+		return false
+	}
+
+	numeric := func(kind reflect.Kind) bool {
+		return kind >= reflect.Int && kind <= reflect.Float64
+	}
+	switch {
+	case numeric(from.Kind()):
+		return numeric(to.Kind())
+	case from.Kind() == reflect.String:
+		return to.Kind() == reflect.String
+	case from.Kind() == reflect.Bool:
+		return to.Kind() == reflect.Bool
+	default:
+		return false
+	}
+}
+
+// parseDate parses the date formats of contentful date fields
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04Z07:00", "2006-01-02T15:04", "2006-01-02"} {
+		if date, err := time.Parse(layout, s); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
@@ -0,0 +1,220 @@
+package contentful
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/foomo/contentful/richtext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mappedCat struct {
+	Sys        *Sys
+	Name       string            `contentful:"name"`
+	Names      map[string]string `contentful:"name,localized"`
+	Lives      int               `contentful:"lives"`
+	Birthday   time.Time         `contentful:"birthday"`
+	Location   *Location         `contentful:"location"`
+	Bio        *richtext.Node    `contentful:"bio"`
+	BestFriend *mappedCat        `contentful:"bestFriend"`
+	Friends    []*mappedCat      `contentful:"friends"`
+	Image      *Asset            `contentful:"image"`
+	ImageLink  *Link             `contentful:"image"`
+	Tags       []string          `contentful:"tags,omitempty"`
+	Ignored    string
+}
+
+const mappingCatsJSON = `[
+	{
+		"sys": {"id": "nyancat", "type": "Entry"},
+		"fields": {
+			"name": {"en-US": "Nyan Cat", "de": "Nyan Katze"},
+			"lives": {"en-US": 1337},
+			"birthday": {"en-US": "2011-04-04T22:00:00Z"},
+			"location": {"en-US": {"lat": 52.5, "lon": 13.4}},
+			"bio": {"en-US": {"nodeType": "document", "content": [{"nodeType": "paragraph"}]}},
+			"bestFriend": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "happycat"}}},
+			"friends": {"en-US": [
+				{"sys": {"type": "Link", "linkType": "Entry", "id": "happycat"}},
+				{"sys": {"type": "Link", "linkType": "Entry", "id": "missing"}}
+			]},
+			"image": {"en-US": {"sys": {"type": "Link", "linkType": "Asset", "id": "nyan"}}}
+		}
+	},
+	{
+		"sys": {"id": "happycat", "type": "Entry"},
+		"fields": {
+			"name": {"en-US": "Happy Cat"},
+			"birthday": {"en-US": "2003-10-28"},
+			"bestFriend": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "nyancat"}}}
+		}
+	}
+]`
+
+func TestDecodeEntry(t *testing.T) {
+	var entries []*Entry
+	require.NoError(t, json.Unmarshal([]byte(mappingCatsJSON), &entries))
+	assets := []*Asset{{Sys: &Sys{ID: "nyan", Type: "Asset"}}}
+	resolver := NewLinkResolver(entries, assets)
+
+	var cat mappedCat
+	require.NoError(t, DecodeEntry(entries[0], &cat, &MappingOptions{Locale: "en-US", Resolver: resolver}))
+
+	assert.Equal(t, "nyancat", cat.Sys.ID)
+	assert.Equal(t, "Nyan Cat", cat.Name)
+	assert.Equal(t, map[string]string{"en-US": "Nyan Cat", "de": "Nyan Katze"}, cat.Names)
+	assert.Equal(t, 1337, cat.Lives)
+	assert.Equal(t, time.Date(2011, 4, 4, 22, 0, 0, 0, time.UTC), cat.Birthday)
+	assert.Equal(t, &Location{Lat: 52.5, Lon: 13.4}, cat.Location)
+	assert.Equal(t, richtext.NodeTypeDocument, cat.Bio.NodeType)
+	assert.Len(t, cat.Bio.Content, 1)
+	assert.Equal(t, "nyan", cat.Image.Sys.ID)
+	assert.Equal(t, NewLink("Asset", "nyan"), cat.ImageLink)
+	assert.Empty(t, cat.Ignored)
+
+	// links back to an entry being decoded are left empty
+	require.NotNil(t, cat.BestFriend)
+	assert.Equal(t, "Happy Cat", cat.BestFriend.Name)
+	assert.Equal(t, time.Date(2003, 10, 28, 0, 0, 0, 0, time.UTC), cat.BestFriend.Birthday)
+	assert.Nil(t, cat.BestFriend.BestFriend)
+
+	// unresolved links are skipped
+	require.Len(t, cat.Friends, 1)
+	assert.Equal(t, "happycat", cat.Friends[0].Sys.ID)
+}
+
+func TestDecodeEntryResolved(t *testing.T) {
+	var entries []*Entry
+	require.NoError(t, json.Unmarshal([]byte(mappingCatsJSON), &entries))
+	resolved := NewLinkResolver(entries, nil).ResolveEntry(entries[1], 1)
+
+	var cat mappedCat
+	require.NoError(t, DecodeEntry(resolved, &cat, &MappingOptions{Locale: "en-US", Depth: 1}))
+	require.NotNil(t, cat.BestFriend)
+	assert.Equal(t, "Nyan Cat", cat.BestFriend.Name)
+	// depth is exhausted
+	assert.Nil(t, cat.BestFriend.BestFriend)
+}
+
+func TestDecodeEntrySingleLocale(t *testing.T) {
+	entry := &Entry{
+		Sys:    &Sys{ID: "nyancat", Type: "Entry", Locale: "de"},
+		Fields: map[string]interface{}{"name": "Nyan Katze", "lives": float64(9)},
+	}
+
+	var cat mappedCat
+	require.NoError(t, DecodeEntry(entry, &cat, nil))
+	assert.Equal(t, "Nyan Katze", cat.Name)
+	assert.Equal(t, map[string]string{"de": "Nyan Katze"}, cat.Names)
+	assert.Equal(t, 9, cat.Lives)
+}
+
+func TestDecodeEntryErrors(t *testing.T) {
+	var entries []*Entry
+	require.NoError(t, json.Unmarshal([]byte(mappingCatsJSON), &entries))
+
+	var cat mappedCat
+	assert.Error(t, DecodeEntry(entries[0], cat, nil))
+	assert.ErrorContains(t, DecodeEntry(entries[0], &cat, nil), "a locale is required")
+
+	var invalid struct {
+		Lives string `contentful:"lives"`
+	}
+	assert.Error(t, DecodeEntry(entries[0], &invalid, &MappingOptions{Locale: "en-US"}))
+}
+
+func TestDecodeEntryDateWithOffset(t *testing.T) {
+	var event struct {
+		When time.Time `contentful:"when"`
+	}
+	// the web app stores dates with minutes and the time zone
+	entry := &Entry{Fields: map[string]interface{}{"when": map[string]interface{}{"en-US": "2021-03-04T00:00+01:00"}}}
+	require.NoError(t, DecodeEntry(entry, &event, &MappingOptions{Locale: "en-US"}))
+	assert.True(t, time.Date(2021, 3, 3, 23, 0, 0, 0, time.UTC).Equal(event.When))
+
+	encoded, err := EncodeEntry(&event, &MappingOptions{Locale: "en-US"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"en-US": "2021-03-04T00:00:00+01:00"}, encoded.Fields["when"])
+}
+
+func TestDecodeEntryNumbers(t *testing.T) {
+	var numbers struct {
+		Int   int     `contentful:"int"`
+		Int8  int8    `contentful:"int8"`
+		Uint  uint    `contentful:"uint"`
+		Float float32 `contentful:"float"`
+	}
+	decode := func(field string, value interface{}) error {
+		entry := &Entry{Fields: map[string]interface{}{field: map[string]interface{}{"en-US": value}}}
+		return DecodeEntry(entry, &numbers, &MappingOptions{Locale: "en-US"})
+	}
+
+	require.NoError(t, decode("int", float64(42)))
+	require.NoError(t, decode("int8", float64(-128)))
+	require.NoError(t, decode("uint", float64(7)))
+	require.NoError(t, decode("float", 1.1))
+	assert.Equal(t, 42, numbers.Int)
+	assert.Equal(t, int8(-128), numbers.Int8)
+	assert.Equal(t, uint(7), numbers.Uint)
+	assert.InDelta(t, 1.1, numbers.Float, 1e-6)
+
+	assert.ErrorContains(t, decode("int", 1.5), `field "int": 1.5 does not fit into int`)
+	assert.ErrorContains(t, decode("int8", float64(300)), "does not fit into int8")
+	assert.ErrorContains(t, decode("uint", float64(-1)), "does not fit into uint")
+	assert.Equal(t, 42, numbers.Int, "the field is not changed")
+}
+
+func TestEncodeEntry(t *testing.T) {
+	cat := &mappedCat{
+		Sys:        &Sys{ID: "nyancat", Version: 3},
+		Name:       "Nyan Cat",
+		Names:      map[string]string{"de": "Nyan Katze"},
+		Lives:      1337,
+		Birthday:   time.Date(2011, 4, 4, 22, 0, 0, 0, time.UTC),
+		Location:   &Location{Lat: 52.5, Lon: 13.4},
+		BestFriend: &mappedCat{Sys: &Sys{ID: "happycat"}},
+		Friends:    []*mappedCat{{Sys: &Sys{ID: "happycat"}}},
+		ImageLink:  NewLink("Asset", "nyan"),
+	}
+
+	entry, err := EncodeEntry(cat, &MappingOptions{Locale: "en-US", ContentType: "cat"})
+	require.NoError(t, err)
+	assert.Equal(t, "nyancat", entry.Sys.ID)
+	assert.Equal(t, 3, entry.GetVersion())
+	assert.Equal(t, "cat", entry.Sys.ContentType.Sys.ID)
+
+	data, err := json.Marshal(entry.Fields)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": {"en-US": "Nyan Cat", "de": "Nyan Katze"},
+		"lives": {"en-US": 1337},
+		"birthday": {"en-US": "2011-04-04T22:00:00Z"},
+		"location": {"en-US": {"lat": 52.5, "lon": 13.4}},
+		"bestFriend": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "happycat"}}},
+		"friends": {"en-US": [{"sys": {"type": "Link", "linkType": "Entry", "id": "happycat"}}]},
+		"image": {"en-US": {"sys": {"type": "Link", "linkType": "Asset", "id": "nyan"}}}
+	}`, string(data))
+	// the sys of the value is not modified
+	assert.Nil(t, cat.Sys.ContentType)
+
+	// round trip
+	var decoded mappedCat
+	require.NoError(t, DecodeEntry(entry, &decoded, &MappingOptions{Locale: "en-US"}))
+	assert.Equal(t, cat.Name, decoded.Name)
+	assert.Equal(t, cat.Lives, decoded.Lives)
+	assert.Equal(t, cat.Birthday, decoded.Birthday)
+	assert.Equal(t, cat.Location, decoded.Location)
+}
+
+func TestEncodeEntryErrors(t *testing.T) {
+	_, err := EncodeEntry(&mappedCat{Name: "Nyan Cat"}, nil)
+	assert.ErrorContains(t, err, "requires a locale")
+
+	_, err = EncodeEntry(&mappedCat{BestFriend: &mappedCat{}}, &MappingOptions{Locale: "en-US"})
+	assert.ErrorContains(t, err, "requires a sys id")
+
+	_, err = EncodeEntry("cat", nil)
+	assert.Error(t, err)
+}
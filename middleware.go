@@ -0,0 +1,96 @@
+package contentful
+
+import (
+	"context"
+	"net/http"
+)
+
+// Doer sends a single http request. *http.Client implements Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as Doer
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req)
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer of the next middleware in the chain
+type Middleware func(next Doer) Doer
+
+// OperationHook is called once per api operation, before its first attempt.
+// The returned context is used by all attempts and end is called with the
+// result of the operation after its last attempt.
+type OperationHook func(ctx context.Context, op Operation) (_ context.Context, end func(err error))
+
+// Operation describes the api call a request has been created for
+type Operation struct {
+	// Service is the name of the client service, e.g. "Entries"
+	Service string
+	// Name is the name of the service method, e.g. "Upsert"
+	Name string
+	// SpaceID of the request, empty for space independent calls
+	SpaceID string
+	// Environment the client is configured for
+	Environment string
+	// EntityID of the entity the call operates on, empty for collections
+	// and newly created entities
+	EntityID string
+}
+
+// String returns the operation as "Service.Name"
+func (op Operation) String() string {
+	return op.Service + "." + op.Name
+}
+
+type operationKey struct{}
+
+// OperationFromContext returns the operation attached to the request context
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+// Use appends middlewares to the chain wrapping every request. The first
+// middleware is the outermost one and each one sees every retry attempt.
+func (c *Contentful) Use(middlewares ...Middleware) *Contentful {
+	c.middlewares = append(c.middlewares, middlewares...)
+	return c
+}
+
+// OnOperation appends hooks called for every api operation. The first hook
+// is the outermost one and each one sees an operation once, no matter how
+// often it is retried.
+func (c *Contentful) OnOperation(hooks ...OperationHook) *Contentful {
+	c.hooks = append(c.hooks, hooks...)
+	return c
+}
+
+// doer returns the http client wrapped by all middlewares
+func (c *Contentful) doer() Doer {
+	var d Doer = c.client
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		d = c.middlewares[i](d)
+	}
+	return d
+}
+
+func (c *Contentful) operation(ctx context.Context, service, name, spaceID, entityID string) context.Context {
+	return context.WithValue(ctx, operationKey{}, Operation{
+		Service:     service,
+		Name:        name,
+		SpaceID:     spaceID,
+		Environment: c.Environment,
+		EntityID:    entityID,
+	})
+}
+
+func sysID(sys *Sys) string {
+	if sys == nil {
+		return ""
+	}
+	return sys.ID
+}
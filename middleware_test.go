@@ -0,0 +1,151 @@
+package contentful
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddlewareOperation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var ops []Operation
+	c.Environment = "staging"
+	c.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			op, ok := OperationFromContext(req.Context())
+			require.True(t, ok)
+			ops = append(ops, op)
+			return next.Do(req)
+		})
+	})
+
+	_, _ = c.Entries.Get(t.Context(), spaceID, "nyancat")
+	_, _ = c.ContentTypes.List(t.Context(), spaceID).Next()
+
+	require.Len(t, ops, 2)
+	assert.Equal(t, Operation{Service: "Entries", Name: "Get", SpaceID: spaceID, Environment: "staging", EntityID: "nyancat"}, ops[0])
+	assert.Equal(t, "Entries.Get", ops[0].String())
+	assert.Equal(t, "ContentTypes.List", ops[1].String())
+	assert.Empty(t, ops[1].EntityID)
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Values("X-Order")
+		_, _ = w.Write([]byte(readTestData(t, "space-1.json")))
+	}))
+	defer server.Close()
+
+	header := func(value string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				req.Header.Add("X-Order", value)
+				return next.Do(req)
+			})
+		}
+	}
+
+	cma := NewCMA(CMAToken).Use(header("first"), header("second"))
+	cma.BaseURL = server.URL
+
+	_, err := cma.Spaces.Get(t.Context(), "id1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, headers)
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(readTestData(t, "space-1.json")))
+	}))
+	defer server.Close()
+
+	var attempts int
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+	cma := NewCMA(CMAToken).SetRetryPolicy(policy).Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Header:     http.Header{},
+					Body:       io.NopCloser(strings.NewReader(`{"sys":{"type":"Error","id":"ServiceUnavailable"}}`)),
+					Request:    req,
+				}, nil
+			}
+			return next.Do(req)
+		})
+	})
+	cma.BaseURL = server.URL
+
+	space, err := cma.Spaces.Get(t.Context(), "id1")
+	require.NoError(t, err)
+	assert.Equal(t, "id1", space.Sys.ID)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestOperationHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"sys":{"type":"Error","id":"NotFound"}}`))
+	}))
+	defer server.Close()
+
+	type hookKey struct{}
+	var calls []string
+	hook := func(name string) OperationHook {
+		return func(ctx context.Context, op Operation) (context.Context, func(err error)) {
+			calls = append(calls, name+" "+op.String())
+			return context.WithValue(ctx, hookKey{}, name), func(err error) {
+				calls = append(calls, name+" end "+err.Error())
+			}
+		}
+	}
+
+	var attempts int
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+	cma, err := New(APICMA, CMAToken,
+		WithBaseURL(server.URL),
+		WithRetryPolicy(policy),
+		WithOperationHook(hook("outer")),
+		WithMiddleware(func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				attempts++
+				// the context of the innermost hook is used by every attempt
+				assert.Equal(t, "inner", req.Context().Value(hookKey{}))
+				if attempts == 1 {
+					return &http.Response{
+						StatusCode: http.StatusServiceUnavailable,
+						Header:     http.Header{},
+						Body:       io.NopCloser(strings.NewReader(`{"sys":{"type":"Error","id":"ServiceUnavailable"}}`)),
+						Request:    req,
+					}, nil
+				}
+				return next.Do(req)
+			})
+		}),
+	)
+	require.NoError(t, err)
+	cma.OnOperation(hook("inner"))
+
+	_, err = cma.Entries.Get(t.Context(), spaceID, "nyancat")
+	require.ErrorAs(t, err, &NotFoundError{})
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, []string{
+		"outer Entries.Get",
+		"inner Entries.Get",
+		"inner end " + err.Error(),
+		"outer end " + err.Error(),
+	}, calls)
+}
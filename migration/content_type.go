@@ -0,0 +1,231 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/foomo/contentful"
+)
+
+// ContentType declares changes of a content type. Every change is a step
+// of the migration.
+type ContentType struct {
+	m  *Migration
+	id string
+}
+
+// CreateContentType declares a new content type, its fields are added with
+// CreateField
+func (m *Migration) CreateContentType(id, name string) *ContentType {
+	m.step(fmt.Sprintf("create content type %q", id), func(ctx context.Context, r *run) error {
+		if _, ok := r.contentTypes[id]; ok {
+			return fmt.Errorf("content type %q already exists", id)
+		}
+		ct := &contentful.ContentType{Sys: &contentful.Sys{ID: id}, Name: name}
+		r.contentTypes[id] = ct
+		r.changed(ct)
+		return nil
+	})
+
+	return &ContentType{m: m, id: id}
+}
+
+// EditContentType declares changes of an existing content type
+func (m *Migration) EditContentType(id string) *ContentType {
+	return &ContentType{m: m, id: id}
+}
+
+// DeleteContentType deactivates and deletes a content type. Its entries have
+// to be deleted before.
+func (m *Migration) DeleteContentType(id string) {
+	m.step(fmt.Sprintf("delete content type %q", id), func(ctx context.Context, r *run) error {
+		ct, err := r.contentType(id)
+		if err != nil {
+			return err
+		}
+		if err := r.flush(ctx); err != nil {
+			return err
+		}
+
+		delete(r.contentTypes, id)
+		if r.dryRun || ct.Sys.Version == 0 {
+			return nil
+		}
+		if ct.Sys.PublishedVersion > 0 {
+			if err := r.env.ContentTypes.Deactivate(ctx, ct); err != nil {
+				return err
+			}
+		}
+		return r.env.ContentTypes.Delete(ctx, ct)
+	})
+}
+
+// ID returns the id of the content type
+func (ct *ContentType) ID() string {
+	return ct.id
+}
+
+// Name changes the name of the content type
+func (ct *ContentType) Name(name string) *ContentType {
+	return ct.edit(fmt.Sprintf("set name to %q", name), func(_ context.Context, _ *run, contentType *contentful.ContentType) error {
+		contentType.Name = name
+		return nil
+	})
+}
+
+// Description changes the description of the content type
+func (ct *ContentType) Description(description string) *ContentType {
+	return ct.edit("set description", func(_ context.Context, _ *run, contentType *contentful.ContentType) error {
+		contentType.Description = description
+		return nil
+	})
+}
+
+// DisplayField changes the field used as title of the entries
+func (ct *ContentType) DisplayField(fieldID string) *ContentType {
+	return ct.edit(fmt.Sprintf("set display field to %q", fieldID), func(_ context.Context, _ *run, contentType *contentful.ContentType) error {
+		if _, err := field(contentType, fieldID); err != nil {
+			return err
+		}
+		contentType.DisplayField = fieldID
+		return nil
+	})
+}
+
+// CreateField adds a field to the content type
+func (ct *ContentType) CreateField(f *contentful.Field) *ContentType {
+	return ct.edit(fmt.Sprintf("create field %q", f.ID), func(ctx context.Context, r *run, contentType *contentful.ContentType) error {
+		if _, err := field(contentType, f.ID); err == nil {
+			return fmt.Errorf("field %q already exists", f.ID)
+		}
+		// a field deleted before is removed before it is created again
+		if slices.Contains(r.deleted, contentType.Sys.ID+"."+f.ID) {
+			if err := r.flush(ctx); err != nil {
+				return err
+			}
+		}
+		created := *f
+		contentType.Fields = append(contentType.Fields, &created)
+		return nil
+	})
+}
+
+// EditField changes the definition of a field, e.g. its name or whether it
+// is required
+func (ct *ContentType) EditField(fieldID string, edit func(field *contentful.Field)) *ContentType {
+	return ct.edit(fmt.Sprintf("edit field %q", fieldID), func(_ context.Context, _ *run, contentType *contentful.ContentType) error {
+		f, err := field(contentType, fieldID)
+		if err != nil {
+			return err
+		}
+		edit(f)
+		return nil
+	})
+}
+
+// SetValidations replaces the validations of a field
+func (ct *ContentType) SetValidations(fieldID string, validations ...contentful.FieldValidation) *ContentType {
+	return ct.edit(fmt.Sprintf("set validations of field %q", fieldID), func(_ context.Context, _ *run, contentType *contentful.ContentType) error {
+		f, err := field(contentType, fieldID)
+		if err != nil {
+			return err
+		}
+		f.Validations = validations
+		return nil
+	})
+}
+
+// RenameField changes the id of a field, keeping the values of the entries.
+// Following steps refer to the field by its new id.
+func (ct *ContentType) RenameField(fieldID, newID string) *ContentType {
+	return ct.edit(fmt.Sprintf("rename field %q to %q", fieldID, newID), func(_ context.Context, _ *run, contentType *contentful.ContentType) error {
+		f, err := field(contentType, fieldID)
+		if err != nil {
+			return err
+		}
+		if _, err := field(contentType, newID); err == nil {
+			return fmt.Errorf("field %q already exists", newID)
+		}
+		if contentType.DisplayField == fieldID {
+			contentType.DisplayField = newID
+		}
+		f.NewID = newID
+		return nil
+	})
+}
+
+// OmitField hides a field from the delivery api
+func (ct *ContentType) OmitField(fieldID string) *ContentType {
+	return ct.edit(fmt.Sprintf("omit field %q", fieldID), func(_ context.Context, _ *run, contentType *contentful.ContentType) error {
+		f, err := field(contentType, fieldID)
+		if err != nil {
+			return err
+		}
+		f.Omitted = true
+		return nil
+	})
+}
+
+// DeleteField deletes a field. Fields which are not omitted yet are omitted
+// and the content type is activated before the field is deleted.
+func (ct *ContentType) DeleteField(fieldID string) *ContentType {
+	return ct.edit(fmt.Sprintf("delete field %q", fieldID), func(ctx context.Context, r *run, contentType *contentful.ContentType) error {
+		f, err := field(contentType, fieldID)
+		if err != nil {
+			return err
+		}
+		if contentType.DisplayField == fieldID {
+			return errors.New("the display field can not be deleted")
+		}
+		if !f.Omitted || slices.Contains(r.dirty, contentType) {
+			f.Omitted = true
+			r.changed(contentType)
+			if err := r.flush(ctx); err != nil {
+				return err
+			}
+		}
+		contentType.Fields = slices.DeleteFunc(contentType.Fields, func(f *contentful.Field) bool {
+			return fieldID == currentID(f)
+		})
+		r.deleted = append(r.deleted, contentType.Sys.ID+"."+fieldID)
+		return nil
+	})
+}
+
+// edit declares a change of the content type
+func (ct *ContentType) edit(description string, fn func(ctx context.Context, r *run, contentType *contentful.ContentType) error) *ContentType {
+	ct.m.step(fmt.Sprintf("content type %q: %s", ct.id, description), func(ctx context.Context, r *run) error {
+		contentType, err := r.contentType(ct.id)
+		if err != nil {
+			return err
+		}
+		if err := fn(ctx, r, contentType); err != nil {
+			return err
+		}
+		r.changed(contentType)
+		return nil
+	})
+
+	return ct
+}
+
+func field(ct *contentful.ContentType, id string) (*contentful.Field, error) {
+	for _, f := range ct.Fields {
+		if currentID(f) == id {
+			return f, nil
+		}
+	}
+
+	return nil, fmt.Errorf("field %q does not exist", id)
+}
+
+// currentID returns the id of a field including a pending rename
+func currentID(f *contentful.Field) string {
+	if f.NewID != "" {
+		return f.NewID
+	}
+
+	return f.ID
+}
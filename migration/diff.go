@@ -0,0 +1,393 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/foomo/contentful"
+)
+
+// ChangeType is the kind of a difference between two content models
+type ChangeType string
+
+// Change types
+const (
+	ContentTypeAdded   ChangeType = "ContentTypeAdded"
+	ContentTypeChanged ChangeType = "ContentTypeChanged"
+	ContentTypeRemoved ChangeType = "ContentTypeRemoved"
+	FieldAdded         ChangeType = "FieldAdded"
+	FieldTypeChanged   ChangeType = "FieldTypeChanged"
+	FieldChanged       ChangeType = "FieldChanged"
+	ValidationsChanged ChangeType = "ValidationsChanged"
+	FieldRemoved       ChangeType = "FieldRemoved"
+)
+
+// Change is a difference between two content models
+type Change struct {
+	Type        ChangeType
+	ContentType string
+	// Field is the id of the changed field, empty for content type changes
+	Field string
+	// Description describes the change, e.g. "required: false -> true"
+	Description string
+}
+
+// Diff lists the changes turning one content model into another, ordered
+// by content type and in the order they can be applied
+type Diff struct {
+	Changes []Change
+
+	to map[string]*contentful.ContentType
+}
+
+// ContentTypes returns all content types of the environment, e.g. to be
+// compared with DiffContentTypes
+func ContentTypes(ctx context.Context, env *contentful.EnvironmentClient) ([]*contentful.ContentType, error) {
+	var contentTypes []*contentful.ContentType
+	for ct, err := range env.ContentTypes.List(ctx).All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		contentTypes = append(contentTypes, &ct)
+	}
+
+	return contentTypes, nil
+}
+
+// DiffContentTypes compares the content types of an environment or an
+// export with the content types they should be turned into, e.g. of the
+// environment to be promoted
+func DiffContentTypes(from, to []*contentful.ContentType) *Diff {
+	d := &Diff{to: byID(to)}
+	current := byID(from)
+
+	ids := slices.Sorted(maps.Keys(current))
+	for id := range d.to {
+		if _, ok := current[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		switch from, to := current[id], d.to[id]; {
+		case from == nil:
+			d.add(Change{Type: ContentTypeAdded, ContentType: id, Description: fmt.Sprintf("%d fields", len(to.Fields))})
+			for _, f := range to.Fields {
+				d.add(Change{Type: FieldAdded, ContentType: id, Field: f.ID, Description: fieldType(f)})
+			}
+		case to == nil:
+			d.add(Change{Type: ContentTypeRemoved, ContentType: id})
+		default:
+			d.diffContentType(from, to)
+		}
+	}
+
+	return d
+}
+
+func byID(contentTypes []*contentful.ContentType) map[string]*contentful.ContentType {
+	m := make(map[string]*contentful.ContentType, len(contentTypes))
+	for _, ct := range contentTypes {
+		if ct != nil && ct.Sys != nil {
+			m[ct.Sys.ID] = ct
+		}
+	}
+
+	return m
+}
+
+func (d *Diff) add(change Change) {
+	d.Changes = append(d.Changes, change)
+}
+
+func (d *Diff) diffContentType(from, to *contentful.ContentType) {
+	id := to.Sys.ID
+	var changed, removed []Change
+	for _, property := range []struct {
+		name     string
+		from, to string
+	}{
+		{"name", from.Name, to.Name},
+		{"description", from.Description, to.Description},
+		{"displayField", from.DisplayField, to.DisplayField},
+	} {
+		if property.from != property.to {
+			changed = append(changed, Change{Type: ContentTypeChanged, ContentType: id, Description: fmt.Sprintf("%s: %q -> %q", property.name, property.from, property.to)})
+		}
+	}
+
+	for _, f := range from.Fields {
+		if _, err := field(to, f.ID); err != nil {
+			removed = append(removed, Change{Type: FieldRemoved, ContentType: id, Field: f.ID, Description: fieldType(f)})
+		}
+	}
+
+	for _, t := range to.Fields {
+		f, err := field(from, t.ID)
+		if err != nil {
+			d.add(Change{Type: FieldAdded, ContentType: id, Field: t.ID, Description: fieldType(t)})
+			continue
+		}
+		if fieldType(f) != fieldType(t) {
+			d.add(Change{Type: FieldTypeChanged, ContentType: id, Field: t.ID, Description: fieldType(f) + " -> " + fieldType(t)})
+			continue
+		}
+		for _, property := range []struct {
+			name     string
+			from, to interface{}
+		}{
+			{"name", f.Name, t.Name},
+			{"required", f.Required, t.Required},
+			{"localized", f.Localized, t.Localized},
+			{"disabled", f.Disabled, t.Disabled},
+			{"omitted", f.Omitted, t.Omitted},
+		} {
+			if property.from != property.to {
+				d.add(Change{Type: FieldChanged, ContentType: id, Field: t.ID, Description: fmt.Sprintf("%s: %#v -> %#v", property.name, property.from, property.to)})
+			}
+		}
+		if description := diffValidations(f, t); description != "" {
+			d.add(Change{Type: ValidationsChanged, ContentType: id, Field: t.ID, Description: description})
+		}
+	}
+
+	// the display field has to change before its field is removed
+	d.Changes = append(d.Changes, changed...)
+	d.Changes = append(d.Changes, removed...)
+}
+
+// diffValidations describes the validations added and removed from the field
+// and its items
+func diffValidations(from, to *contentful.Field) string {
+	var changes []string
+	describe := func(prefix string, from, to []contentful.FieldValidation) {
+		f := describeValidations(from)
+		t := describeValidations(to)
+		for _, v := range t {
+			if !slices.Contains(f, v) {
+				changes = append(changes, prefix+"+ "+v)
+			}
+		}
+		for _, v := range f {
+			if !slices.Contains(t, v) {
+				changes = append(changes, prefix+"- "+v)
+			}
+		}
+	}
+
+	describe("", from.Validations, to.Validations)
+	if from.Items != nil && to.Items != nil {
+		describe("items ", from.Items.Validations, to.Items.Validations)
+	}
+
+	if len(changes) == 0 {
+		return ""
+	}
+
+	return "validations: " + strings.Join(changes, ", ")
+}
+
+func describeValidations(validations []contentful.FieldValidation) []string {
+	descriptions := make([]string, 0, len(validations))
+	for _, v := range validations {
+		descriptions = append(descriptions, describeValidation(v))
+	}
+
+	return descriptions
+}
+
+// describeValidation returns a readable representation of a validation
+func describeValidation(validation contentful.FieldValidation) string {
+	if rv := reflect.ValueOf(validation); rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		validation = rv.Elem().Interface()
+	}
+
+	var s, message string
+	switch v := validation.(type) {
+	case contentful.FieldValidationSize:
+		s, message = "size("+describeMinMax(v.Size)+")", v.ErrorMessage
+	case contentful.FieldValidationRange:
+		s, message = "range("+describeMinMax(v.Range)+")", v.ErrorMessage
+	case contentful.FieldValidationDate:
+		var limits []string
+		if v.Range != nil && !v.Range.Min.IsZero() {
+			limits = append(limits, "min "+v.Range.Min.Format("2006-01-02T15:04:05"))
+		}
+		if v.Range != nil && !v.Range.Max.IsZero() {
+			limits = append(limits, "max "+v.Range.Max.Format("2006-01-02T15:04:05"))
+		}
+		s, message = "dateRange("+strings.Join(limits, ", ")+")", v.ErrorMessage
+	case contentful.FieldValidationRegex:
+		if v.Regex != nil {
+			s = "regexp(/" + v.Regex.Pattern + "/" + v.Regex.Flags + ")"
+		}
+		message = v.ErrorMessage
+	case contentful.FieldValidationPredefinedValues:
+		s, message = fmt.Sprintf("in(%v)", v.In), v.ErrorMessage
+	case contentful.FieldValidationLink:
+		s = fmt.Sprintf("linkContentType(%v)", v.LinkContentType)
+	case contentful.FieldValidationMimeType:
+		s = fmt.Sprintf("linkMimetypeGroup(%v)", v.MimeTypes)
+	case contentful.FieldValidationDimension:
+		s = "assetImageDimensions(width " + describeMinMax(v.Width) + "; height " + describeMinMax(v.Height) + ")"
+		message = v.ErrorMessage
+	case contentful.FieldValidationFileSize:
+		s, message = "assetFileSize("+describeMinMax(v.Size)+")", v.ErrorMessage
+	case contentful.FieldValidationUnique:
+		s = "unique"
+	case contentful.FieldValidationEnabledNodeTypes:
+		s, message = fmt.Sprintf("enabledNodeTypes(%v)", v.EnabledNodeTypes), v.ErrorMessage
+	case contentful.FieldValidationEnabledMarks:
+		s, message = fmt.Sprintf("enabledMarks(%v)", v.EnabledMarks), v.ErrorMessage
+	default:
+		data, _ := json.Marshal(v)
+		s = string(data)
+	}
+
+	if message != "" {
+		s += fmt.Sprintf(" %q", message)
+	}
+
+	return s
+}
+
+func describeMinMax(minMax *contentful.MinMax) string {
+	var limits []string
+	if minMax != nil && minMax.HasMin() {
+		limits = append(limits, fmt.Sprintf("min %v", minMax.Min))
+	}
+	if minMax != nil && minMax.HasMax() {
+		limits = append(limits, fmt.Sprintf("max %v", minMax.Max))
+	}
+
+	return strings.Join(limits, ", ")
+}
+
+// fieldType returns the type of a field including its link and item types,
+// e.g. Array<Link<Entry>>
+func fieldType(f *contentful.Field) string {
+	t := f.Type
+	if f.LinkType != "" {
+		t += "<" + f.LinkType + ">"
+	}
+	if f.Items != nil {
+		item := f.Items.Type
+		if f.Items.LinkType != "" {
+			item += "<" + f.Items.LinkType + ">"
+		}
+		t += "<" + item + ">"
+	}
+
+	return t
+}
+
+// Empty reports whether the content models are equal
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// String returns a readable report of the changes
+func (d *Diff) String() string {
+	if d.Empty() {
+		return "no changes\n"
+	}
+
+	var b strings.Builder
+	for _, change := range d.Changes {
+		b.WriteString(change.String() + "\n")
+	}
+
+	return b.String()
+}
+
+// String describes the change on a single line
+func (c Change) String() string {
+	var s string
+	switch c.Type {
+	case ContentTypeAdded:
+		s = fmt.Sprintf("+ content type %q", c.ContentType)
+	case ContentTypeRemoved:
+		s = fmt.Sprintf("- content type %q", c.ContentType)
+	case ContentTypeChanged:
+		s = fmt.Sprintf("~ content type %q", c.ContentType)
+	case FieldAdded:
+		s = fmt.Sprintf("+ field %q", c.ContentType+"."+c.Field)
+	case FieldRemoved:
+		s = fmt.Sprintf("- field %q", c.ContentType+"."+c.Field)
+	default:
+		s = fmt.Sprintf("~ field %q", c.ContentType+"."+c.Field)
+	}
+	if c.Description != "" {
+		s += ": " + c.Description
+	}
+
+	return s
+}
+
+// Migration returns a migration with the steps turning the first content
+// model of the diff into the second one. The type of a field can not be
+// changed, changed fields are deleted and created again which drops their
+// values.
+func (d *Diff) Migration(id string) *Migration {
+	m := New(id)
+	edited := map[string]bool{}
+	for _, change := range d.Changes {
+		to := d.to[change.ContentType]
+		switch change.Type {
+		case ContentTypeAdded:
+			ct := m.CreateContentType(change.ContentType, to.Name)
+			if to.Description != "" {
+				ct.Description(to.Description)
+			}
+		case ContentTypeRemoved:
+			m.DeleteContentType(change.ContentType)
+		case ContentTypeChanged:
+			if edited[change.ContentType] {
+				continue
+			}
+			edited[change.ContentType] = true
+			m.EditContentType(change.ContentType).
+				Name(to.Name).
+				Description(to.Description)
+			if to.DisplayField != "" {
+				m.EditContentType(change.ContentType).DisplayField(to.DisplayField)
+			}
+		case FieldAdded:
+			f, _ := field(to, change.Field)
+			m.EditContentType(change.ContentType).CreateField(f)
+			if to.DisplayField == f.ID && !slices.ContainsFunc(d.Changes, func(c Change) bool {
+				return c.Type == ContentTypeChanged && c.ContentType == change.ContentType
+			}) {
+				m.EditContentType(change.ContentType).DisplayField(f.ID)
+			}
+		case FieldTypeChanged:
+			f, _ := field(to, change.Field)
+			m.EditContentType(change.ContentType).
+				DeleteField(f.ID).
+				CreateField(f)
+		case FieldChanged, ValidationsChanged:
+			t, _ := field(to, change.Field)
+			m.EditContentType(change.ContentType).EditField(change.Field, func(f *contentful.Field) {
+				f.Name = t.Name
+				f.Required = t.Required
+				f.Localized = t.Localized
+				f.Disabled = t.Disabled
+				f.Omitted = t.Omitted
+				f.Validations = t.Validations
+				if f.Items != nil && t.Items != nil {
+					f.Items.Validations = t.Items.Validations
+				}
+			})
+		case FieldRemoved:
+			m.EditContentType(change.ContentType).DeleteField(change.Field)
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,86 @@
+package migration
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/foomo/contentful"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func diffContentTypes(t *testing.T, data string) []*contentful.ContentType {
+	t.Helper()
+	var contentTypes []*contentful.ContentType
+	require.NoError(t, json.Unmarshal([]byte(data), &contentTypes))
+
+	return contentTypes
+}
+
+var (
+	diffFrom = `[
+		{"sys": {"id": "cat", "version": 2, "publishedVersion": 1}, "name": "Cat", "displayField": "name", "fields": [
+			{"id": "name", "name": "Name", "type": "Symbol", "validations": [{"size": {"max": 20}}]},
+			{"id": "lives", "name": "Lives", "type": "Symbol"},
+			{"id": "friends", "name": "Friends", "type": "Array", "items": {"type": "Link", "linkType": "Entry", "validations": [{"linkContentType": ["cat"]}]}},
+			{"id": "legacy", "name": "Legacy", "type": "Text"}
+		]},
+		{"sys": {"id": "mouse", "version": 2, "publishedVersion": 1}, "name": "Mouse", "fields": []}
+	]`
+	diffTo = `[
+		{"sys": {"id": "cat"}, "name": "Cats", "displayField": "name", "fields": [
+			{"id": "name", "name": "Name", "type": "Symbol", "required": true, "validations": [{"size": {"min": 2, "max": 20}, "message": "too long"}]},
+			{"id": "lives", "name": "Lives", "type": "Integer"},
+			{"id": "friends", "name": "Friends", "type": "Array", "items": {"type": "Link", "linkType": "Entry", "validations": [{"linkContentType": ["cat", "dog"]}]}},
+			{"id": "color", "name": "Color", "type": "Symbol", "validations": [{"in": ["black", "white"]}]}
+		]},
+		{"sys": {"id": "dog"}, "name": "Dog", "displayField": "name", "fields": [
+			{"id": "name", "name": "Name", "type": "Symbol"}
+		]}
+	]`
+)
+
+func TestDiffContentTypes(t *testing.T) {
+	diff := DiffContentTypes(diffContentTypes(t, diffFrom), diffContentTypes(t, diffTo))
+
+	assert.Equal(t, `~ field "cat.name": required: false -> true
+~ field "cat.name": validations: + size(min 2, max 20) "too long", - size(max 20)
+~ field "cat.lives": Symbol -> Integer
+~ field "cat.friends": validations: items + linkContentType([cat dog]), items - linkContentType([cat])
++ field "cat.color": Symbol
+~ content type "cat": name: "Cat" -> "Cats"
+- field "cat.legacy": Text
++ content type "dog": 1 fields
++ field "dog.name": Symbol
+- content type "mouse"
+`, diff.String())
+
+	assert.True(t, DiffContentTypes(diffContentTypes(t, diffTo), diffContentTypes(t, diffTo)).Empty())
+	assert.Equal(t, "no changes\n", DiffContentTypes(nil, nil).String())
+}
+
+func TestDiffMigration(t *testing.T) {
+	f, env := newFakeEnvironment(t)
+	for _, ct := range diffContentTypes(t, diffFrom) {
+		data, err := json.Marshal(ct)
+		require.NoError(t, err)
+		var stored, published map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &stored))
+		require.NoError(t, json.Unmarshal(data, &published))
+		stored["sys"] = map[string]interface{}{"id": ct.Sys.ID, "type": "ContentType", "version": float64(2), "publishedVersion": float64(1)}
+		f.Items["content_types"] = append(f.Items["content_types"], stored)
+		f.Published[ct.Sys.ID] = published
+	}
+
+	diff := DiffContentTypes(diffContentTypes(t, diffFrom), diffContentTypes(t, diffTo))
+	_, err := diff.Migration("converge").Apply(t.Context(), env)
+	require.NoError(t, err)
+
+	contentTypes, err := ContentTypes(t.Context(), env)
+	require.NoError(t, err)
+	contentTypes = slices.DeleteFunc(contentTypes, func(ct *contentful.ContentType) bool {
+		return ct.Sys.ID == DefaultTrackingContentType
+	})
+	assert.Equal(t, "no changes\n", DiffContentTypes(contentTypes, diffContentTypes(t, diffTo)).String())
+}
@@ -0,0 +1,188 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/foomo/contentful"
+)
+
+// TransformFunc changes the fields of an entry in place and reports whether
+// the entry has been changed
+type TransformFunc func(entry *contentful.Entry) (bool, error)
+
+// DeriveOptions configures DeriveLinkedEntries
+type DeriveOptions struct {
+	// ContentType of the entries the new entries are derived from
+	ContentType string
+	// DerivedContentType of the new entries
+	DerivedContentType string
+	// ReferenceField is the link field of the source entries the derived
+	// entry is linked with in the default locale. Entries with a value are
+	// skipped.
+	ReferenceField string
+	// ID returns the id of the derived entry. Source entries returning the
+	// same id link the same derived entry.
+	ID func(entry *contentful.Entry) (string, error)
+	// Derive returns the fields of the derived entry by field id and locale
+	Derive func(entry *contentful.Entry) (map[string]interface{}, error)
+}
+
+// TransformEntries changes the entries of a content type. Changed entries
+// are updated and published again if they were published and up to date.
+// Archived entries are skipped.
+func (m *Migration) TransformEntries(contentType string, transform TransformFunc) {
+	m.step(fmt.Sprintf("transform entries of content type %q", contentType), func(ctx context.Context, r *run) error {
+		if _, err := r.contentType(contentType); err != nil {
+			return err
+		}
+		if err := r.flush(ctx); err != nil || r.dryRun {
+			return err
+		}
+
+		entries, err := r.entries(ctx, contentType)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			changed, err := transform(entry)
+			if err != nil {
+				return fmt.Errorf("entry %q: %w", entry.Sys.ID, err)
+			}
+			if changed {
+				if err := r.update(ctx, entry, isPublished(entry)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// DeriveLinkedEntries creates entries of another content type from the
+// entries of a content type and links them, e.g. to move address fields
+// into an address content type. Derived entries are published if the source
+// entry was published.
+func (m *Migration) DeriveLinkedEntries(opts DeriveOptions) {
+	m.step(fmt.Sprintf("derive entries of content type %q from content type %q", opts.DerivedContentType, opts.ContentType), func(ctx context.Context, r *run) error {
+		if opts.ID == nil || opts.Derive == nil {
+			return errors.New("deriving entries requires an ID and a Derive function")
+		}
+		ct, err := r.contentType(opts.ContentType)
+		if err != nil {
+			return err
+		}
+		if _, err := r.contentType(opts.DerivedContentType); err != nil {
+			return err
+		}
+		if f, err := field(ct, opts.ReferenceField); err != nil {
+			return err
+		} else if f.Type != contentful.FieldTypeLink || f.LinkType != "Entry" {
+			return fmt.Errorf("field %q is not a link to an entry", opts.ReferenceField)
+		}
+		if err := r.flush(ctx); err != nil || r.dryRun {
+			return err
+		}
+
+		locale, err := r.defaultLocale(ctx)
+		if err != nil {
+			return err
+		}
+		entries, err := r.entries(ctx, opts.ContentType)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if values, ok := entry.Fields[opts.ReferenceField].(map[string]interface{}); ok && values[locale] != nil {
+				continue
+			}
+
+			published := isPublished(entry)
+			id, err := r.derive(ctx, entry, opts, published)
+			if err != nil {
+				return fmt.Errorf("entry %q: %w", entry.Sys.ID, err)
+			}
+
+			if entry.Fields == nil {
+				entry.Fields = map[string]interface{}{}
+			}
+			values, _ := entry.Fields[opts.ReferenceField].(map[string]interface{})
+			if values == nil {
+				values = map[string]interface{}{}
+			}
+			values[locale] = contentful.NewLink("Entry", id)
+			entry.Fields[opts.ReferenceField] = values
+			if err := r.update(ctx, entry, published); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// derive creates the derived entry of the source entry unless it exists
+func (r *run) derive(ctx context.Context, entry *contentful.Entry, opts DeriveOptions, publish bool) (string, error) {
+	id, err := opts.ID(entry)
+	if err != nil {
+		return "", err
+	}
+
+	var notFound contentful.NotFoundError
+	switch _, err := r.env.Entries.Get(ctx, id); {
+	case err == nil:
+		return id, nil
+	case !errors.As(err, &notFound):
+		return "", err
+	}
+
+	fields, err := opts.Derive(entry)
+	if err != nil {
+		return "", err
+	}
+	derived := &contentful.Entry{
+		Sys:    &contentful.Sys{ID: id, ContentType: &contentful.ContentType{Sys: &contentful.Sys{ID: opts.DerivedContentType}}},
+		Fields: fields,
+	}
+
+	return id, r.update(ctx, derived, publish)
+}
+
+// entries returns all entries of the content type
+func (r *run) entries(ctx context.Context, contentType string) ([]*contentful.Entry, error) {
+	col := r.env.Entries.List(ctx)
+	col.Query.ContentType(contentType)
+
+	var entries []*contentful.Entry
+	for entry, err := range col.All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		if entry.Sys.ArchivedVersion > 0 {
+			continue
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
+
+// update updates the entry and publishes it if requested
+func (r *run) update(ctx context.Context, entry *contentful.Entry, publish bool) error {
+	if err := r.env.Entries.Upsert(ctx, entry); err != nil {
+		return fmt.Errorf("updating entry %q: %w", entry.Sys.ID, err)
+	}
+	if !publish {
+		return nil
+	}
+	if err := r.env.Entries.Publish(ctx, entry); err != nil {
+		return fmt.Errorf("publishing entry %q: %w", entry.Sys.ID, err)
+	}
+
+	return nil
+}
+
+// isPublished reports whether the entry is published without pending changes
+func isPublished(entry *contentful.Entry) bool {
+	return entry.Sys.PublishedVersion > 0 && entry.Sys.Version == entry.Sys.PublishedVersion+1
+}
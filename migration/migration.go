@@ -0,0 +1,266 @@
+// Package migration changes the content model of an environment with
+// declared steps. A migration is planned as a dry run or applied with
+// version handling. Applied migrations are recorded in a tracking content
+// type and skipped when they are applied again.
+//
+//	m := migration.New("2024-06-01-cats")
+//	cat := m.CreateContentType("cat", "Cat")
+//	cat.CreateField(&contentful.Field{ID: "name", Name: "Name", Type: contentful.FieldTypeSymbol})
+//	m.EditContentType("dog").DeleteField("legacy")
+//
+//	plan, err := m.Apply(ctx, cma.Space("space-id").Environment("master"))
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/foomo/contentful"
+)
+
+// DefaultTrackingContentType is the id of the content type applied
+// migrations are recorded in
+const DefaultTrackingContentType = "migration"
+
+var idRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
+
+// Migration is a list of steps applied in the order they are declared
+type Migration struct {
+	// ID identifies the migration in the tracking content type and has to
+	// be a valid entry id
+	ID string
+	// TrackingContentType is the id of the content type applied migrations
+	// are recorded in, defaults to DefaultTrackingContentType
+	TrackingContentType string
+
+	steps []*step
+}
+
+// Plan describes the changes of a migration
+type Plan struct {
+	// Migration is the id of the migration
+	Migration string
+	// Applied reports whether the migration has been applied before
+	Applied bool
+	// Changes describes the steps of the migration in order
+	Changes []string
+}
+
+// step is a change with its description
+type step struct {
+	description string
+	run         func(ctx context.Context, r *run) error
+}
+
+// New returns an empty migration with the given id
+func New(id string) *Migration {
+	return &Migration{ID: id}
+}
+
+// String lists the changes of the plan
+func (p *Plan) String() string {
+	if p.Applied {
+		return fmt.Sprintf("migration %q has already been applied\n", p.Migration)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "migration %q:\n", p.Migration)
+	for _, change := range p.Changes {
+		fmt.Fprintf(&b, "  - %s\n", change)
+	}
+
+	return b.String()
+}
+
+// Plan runs the migration as a dry run against the content types of the
+// environment. Steps referring to missing content types or fields fail
+// as they would when applied, entries are not read.
+func (m *Migration) Plan(ctx context.Context, env *contentful.EnvironmentClient) (*Plan, error) {
+	return m.run(ctx, env, true)
+}
+
+// Apply applies the migration to the environment and records it in the
+// tracking content type. Content types are updated and activated as late
+// as possible, fields are omitted and activated before they are deleted.
+// A migration which has been applied before is skipped.
+func (m *Migration) Apply(ctx context.Context, env *contentful.EnvironmentClient) (*Plan, error) {
+	return m.run(ctx, env, false)
+}
+
+func (m *Migration) step(description string, fn func(ctx context.Context, r *run) error) {
+	m.steps = append(m.steps, &step{description: description, run: fn})
+}
+
+func (m *Migration) trackingContentType() string {
+	if m.TrackingContentType != "" {
+		return m.TrackingContentType
+	}
+
+	return DefaultTrackingContentType
+}
+
+func (m *Migration) run(ctx context.Context, env *contentful.EnvironmentClient, dryRun bool) (*Plan, error) {
+	if !idRegex.MatchString(m.ID) {
+		return nil, fmt.Errorf("migration id %q is not a valid entry id", m.ID)
+	}
+
+	plan := &Plan{Migration: m.ID}
+	applied, err := m.applied(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	if applied {
+		plan.Applied = true
+		return plan, nil
+	}
+
+	contentTypes, err := ContentTypes(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &run{env: env, dryRun: dryRun, contentTypes: byID(contentTypes)}
+
+	for _, step := range m.steps {
+		if err := step.run(ctx, r); err != nil {
+			return plan, fmt.Errorf("migration %q: %s: %w", m.ID, step.description, err)
+		}
+		plan.Changes = append(plan.Changes, step.description)
+	}
+
+	if err := r.flush(ctx); err != nil {
+		return plan, fmt.Errorf("migration %q: %w", m.ID, err)
+	}
+	if dryRun {
+		return plan, nil
+	}
+
+	return plan, m.track(ctx, r)
+}
+
+// applied reports whether the migration has been recorded in the tracking
+// content type
+func (m *Migration) applied(ctx context.Context, env *contentful.EnvironmentClient) (bool, error) {
+	entry, err := env.Entries.Get(ctx, m.ID)
+	var notFound contentful.NotFoundError
+	switch {
+	case errors.As(err, &notFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return entry.Sys.ContentType != nil && entry.Sys.ContentType.Sys.ID == m.trackingContentType(), nil
+}
+
+// track records the migration, creating the tracking content type if needed
+func (m *Migration) track(ctx context.Context, r *run) error {
+	id := m.trackingContentType()
+	if _, ok := r.contentTypes[id]; !ok {
+		ct := &contentful.ContentType{
+			Sys:          &contentful.Sys{ID: id},
+			Name:         "Migration",
+			DisplayField: "id",
+			Fields: []*contentful.Field{
+				{ID: "id", Name: "ID", Type: contentful.FieldTypeSymbol, Required: true},
+				{ID: "appliedAt", Name: "Applied at", Type: contentful.FieldTypeDate, Required: true},
+			},
+		}
+		r.contentTypes[id] = ct
+		r.changed(ct)
+		if err := r.flush(ctx); err != nil {
+			return err
+		}
+	}
+
+	locale, err := r.defaultLocale(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.env.Entries.Upsert(ctx, &contentful.Entry{
+		Sys: &contentful.Sys{ID: m.ID, ContentType: &contentful.ContentType{Sys: &contentful.Sys{ID: id}}},
+		Fields: map[string]interface{}{
+			"id":        map[string]interface{}{locale: m.ID},
+			"appliedAt": map[string]interface{}{locale: time.Now().UTC().Format(time.RFC3339)},
+		},
+	})
+}
+
+// run holds the content types while the steps of a migration run
+type run struct {
+	env          *contentful.EnvironmentClient
+	dryRun       bool
+	contentTypes map[string]*contentful.ContentType
+	dirty        []*contentful.ContentType
+	deleted      []string
+	locale       string
+}
+
+func (r *run) contentType(id string) (*contentful.ContentType, error) {
+	ct, ok := r.contentTypes[id]
+	if !ok {
+		return nil, fmt.Errorf("content type %q does not exist", id)
+	}
+
+	return ct, nil
+}
+
+// changed marks the content type to be updated with the next flush
+func (r *run) changed(ct *contentful.ContentType) {
+	if !slices.Contains(r.dirty, ct) {
+		r.dirty = append(r.dirty, ct)
+	}
+}
+
+// flush updates and activates the changed content types
+func (r *run) flush(ctx context.Context) error {
+	dirty := r.dirty
+	r.dirty = nil
+	r.deleted = nil
+	for _, ct := range dirty {
+		if !r.dryRun {
+			if err := r.env.ContentTypes.Upsert(ctx, ct); err != nil {
+				return fmt.Errorf("updating content type %q: %w", ct.Sys.ID, err)
+			}
+			if err := r.env.ContentTypes.Activate(ctx, ct); err != nil {
+				return fmt.Errorf("activating content type %q: %w", ct.Sys.ID, err)
+			}
+		}
+
+		// renamed fields are known by their new id from now on
+		for _, field := range ct.Fields {
+			if field.NewID != "" {
+				field.ID = field.NewID
+				field.NewID = ""
+			}
+		}
+	}
+
+	return nil
+}
+
+// defaultLocale returns the code of the default locale of the environment
+func (r *run) defaultLocale(ctx context.Context) (string, error) {
+	if r.locale != "" {
+		return r.locale, nil
+	}
+
+	col, err := r.env.Locales.List(ctx).GetAll()
+	if err != nil {
+		return "", err
+	}
+	for _, locale := range col.Items {
+		if locale.Default {
+			r.locale = locale.Code
+			return r.locale, nil
+		}
+	}
+
+	return "", errors.New("environment has no default locale")
+}
@@ -0,0 +1,168 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/foomo/contentful"
+	"github.com/foomo/contentful/internal/cmafake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeLocales = `{"locales": [{"sys": {"id": "en"}, "code": "en-US", "default": true}]}`
+
+func newFakeEnvironment(t *testing.T) (*cmafake.Space, *contentful.EnvironmentClient) {
+	t.Helper()
+
+	f, space := cmafake.New(t, fakeLocales)
+
+	return f, space.Environment("master")
+}
+
+func addEntry(f *cmafake.Space, id, contentType string, fields map[string]interface{}, published bool) {
+	sys := map[string]interface{}{
+		"id": id, "type": "Entry", "version": float64(1),
+		"contentType": map[string]interface{}{"sys": map[string]interface{}{"id": contentType, "type": "Link", "linkType": "ContentType"}},
+	}
+	if published {
+		sys["publishedVersion"] = float64(1)
+		sys["version"] = float64(2)
+	}
+	f.Items["entries"] = append(f.Items["entries"], map[string]interface{}{"sys": sys, "fields": fields})
+}
+
+func dogMigration() *Migration {
+	m := New("2024-06-01-dogs")
+	dog := m.CreateContentType("dog", "Dog")
+	dog.CreateField(&contentful.Field{ID: "name", Name: "Name", Type: contentful.FieldTypeSymbol, Required: true})
+	dog.CreateField(&contentful.Field{ID: "lifes", Name: "Lives", Type: contentful.FieldTypeInteger})
+	dog.CreateField(&contentful.Field{ID: "legacy", Name: "Legacy", Type: contentful.FieldTypeText})
+	dog.DisplayField("name")
+	dog.RenameField("lifes", "lives")
+	dog.SetValidations("lives", contentful.FieldValidationRange{Range: &contentful.MinMax{Min: 1, Max: 9}})
+	dog.DeleteField("legacy")
+
+	return m
+}
+
+func TestPlan(t *testing.T) {
+	f, env := newFakeEnvironment(t)
+
+	plan, err := dogMigration().Plan(t.Context(), env)
+	require.NoError(t, err)
+	assert.False(t, plan.Applied)
+	assert.Equal(t, `migration "2024-06-01-dogs":
+  - create content type "dog"
+  - content type "dog": create field "name"
+  - content type "dog": create field "lifes"
+  - content type "dog": create field "legacy"
+  - content type "dog": set display field to "name"
+  - content type "dog": rename field "lifes" to "lives"
+  - content type "dog": set validations of field "lives"
+  - content type "dog": delete field "legacy"
+`, plan.String())
+
+	// a dry run only reads
+	for _, request := range f.Requests {
+		assert.Regexp(t, "^GET ", request)
+	}
+
+	m := New("broken")
+	m.EditContentType("cat").OmitField("name")
+	_, err = m.Plan(t.Context(), env)
+	assert.EqualError(t, err, `migration "broken": content type "cat": omit field "name": content type "cat" does not exist`)
+}
+
+func TestApply(t *testing.T) {
+	f, env := newFakeEnvironment(t)
+
+	plan, err := dogMigration().Apply(t.Context(), env)
+	require.NoError(t, err)
+	assert.Len(t, plan.Changes, 8)
+
+	// the field is omitted and activated before it is deleted
+	assert.Equal(t, []string{
+		"GET /environments/master/entries/2024-06-01-dogs",
+		"GET /environments/master/content_types?limit=100",
+		"PUT /environments/master/content_types/dog",
+		"PUT /environments/master/content_types/dog/published",
+		"PUT /environments/master/content_types/dog",
+		"PUT /environments/master/content_types/dog/published",
+		"PUT /environments/master/content_types/migration",
+		"PUT /environments/master/content_types/migration/published",
+		"GET /environments/master/locales?limit=100",
+		"PUT /environments/master/entries/2024-06-01-dogs",
+	}, f.Requests)
+
+	var ids []string
+	for _, field := range f.Published["dog"]["fields"].([]interface{}) {
+		ids = append(ids, field.(map[string]interface{})["id"].(string))
+	}
+	assert.Equal(t, []string{"name", "lives"}, ids)
+	assert.Equal(t, "name", f.Published["dog"]["displayField"])
+
+	record := f.Find("entries", "2024-06-01-dogs")
+	assert.Equal(t, "2024-06-01-dogs", record["fields"].(map[string]interface{})["id"].(map[string]interface{})["en-US"])
+
+	// applied migrations are skipped
+	f.Requests = nil
+	plan, err = dogMigration().Apply(t.Context(), env)
+	require.NoError(t, err)
+	assert.True(t, plan.Applied)
+	assert.Equal(t, []string{"GET /environments/master/entries/2024-06-01-dogs"}, f.Requests)
+}
+
+func TestApplyEntries(t *testing.T) {
+	f, env := newFakeEnvironment(t)
+	require.NoError(t, func() error {
+		_, err := dogMigration().Apply(t.Context(), env)
+		return err
+	}())
+
+	f.Items["content_types"] = append(f.Items["content_types"], map[string]interface{}{
+		"sys":    map[string]interface{}{"id": "address", "type": "ContentType", "version": float64(2), "publishedVersion": float64(1)},
+		"name":   "Address",
+		"fields": []interface{}{map[string]interface{}{"id": "city", "name": "City", "type": "Symbol"}},
+	})
+	addEntry(f, "nyan", "dog", map[string]interface{}{"name": map[string]interface{}{"en-US": "nyan"}, "lives": map[string]interface{}{"en-US": 9}}, true)
+	addEntry(f, "happy", "dog", map[string]interface{}{"name": map[string]interface{}{"en-US": "happy"}}, false)
+
+	m := New("2024-06-02-addresses")
+	m.EditContentType("dog").
+		CreateField(&contentful.Field{ID: "address", Name: "Address", Type: contentful.FieldTypeLink, LinkType: "Entry"})
+	m.TransformEntries("dog", func(entry *contentful.Entry) (bool, error) {
+		name := entry.Fields["name"].(map[string]interface{})
+		name["en-US"] = name["en-US"].(string) + " dog"
+		return true, nil
+	})
+	m.DeriveLinkedEntries(DeriveOptions{
+		ContentType:        "dog",
+		DerivedContentType: "address",
+		ReferenceField:     "address",
+		ID: func(entry *contentful.Entry) (string, error) {
+			return "berlin", nil
+		},
+		Derive: func(entry *contentful.Entry) (map[string]interface{}, error) {
+			return map[string]interface{}{"city": map[string]interface{}{"en-US": "Berlin"}}, nil
+		},
+	})
+
+	_, err := m.Apply(t.Context(), env)
+	require.NoError(t, err)
+
+	nyan := f.Find("entries", "nyan")
+	assert.Equal(t, "nyan dog", nyan["fields"].(map[string]interface{})["name"].(map[string]interface{})["en-US"])
+	assert.Equal(t, "berlin", nyan["fields"].(map[string]interface{})["address"].(map[string]interface{})["en-US"].(map[string]interface{})["sys"].(map[string]interface{})["id"])
+	// published entries are published again
+	nyanSys := nyan["sys"].(map[string]interface{})
+	assert.Equal(t, nyanSys["version"], nyanSys["publishedVersion"].(float64)+1)
+
+	happySys := f.Find("entries", "happy")["sys"].(map[string]interface{})
+	assert.Nil(t, happySys["publishedVersion"])
+	assert.Equal(t, "berlin", f.Find("entries", "happy")["fields"].(map[string]interface{})["address"].(map[string]interface{})["en-US"].(map[string]interface{})["sys"].(map[string]interface{})["id"])
+
+	// source entries with the same id share the derived entry
+	berlin := f.Find("entries", "berlin")
+	require.NotNil(t, berlin)
+	assert.Equal(t, "address", berlin["sys"].(map[string]interface{})["contentType"].(map[string]interface{})["sys"].(map[string]interface{})["id"])
+}
@@ -0,0 +1,410 @@
+// Package offline answers the read requests of the delivery and preview
+// apis from an export snapshot instead of the network. It plugs into any
+// client as its http transport, so code and tests keep using the regular
+// services.
+//
+//	t, _ := offline.Open("export")
+//	cda, _ := contentful.New(contentful.APICDA, "token", contentful.WithHTTPClient(t.Client()))
+//	col := cda.Space("space-id").Environment("master").Entries.List(ctx)
+//	col.Query.ContentType("cat").Equal("fields.name", "Happy Cat").Include(2)
+package offline
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/foomo/contentful/transfer"
+)
+
+// defaultLocale is used when the snapshot has no default locale
+const defaultLocale = "en-US"
+
+// routeRegex matches the supported paths, with or without environment
+var routeRegex = regexp.MustCompile(`^/spaces/([^/]+)(?:/environments/([^/]+))?/(entries|assets|content_types|locales|tags)(?:/([^/]+))?$`)
+
+// Transport is a http.RoundTripper serving GET requests for entries, assets,
+// content types, locales and tags from a snapshot. Any space and environment
+// is served from the same snapshot. Other requests fail with NotFound.
+type Transport struct {
+	// Preview serves entities which have never been published, as the
+	// preview api does. It is enabled for requests to a preview host.
+	Preview bool
+
+	entries       []map[string]interface{}
+	assets        []map[string]interface{}
+	contentTypes  []map[string]interface{}
+	locales       []map[string]interface{}
+	tags          []map[string]interface{}
+	defaultLocale string
+	fallbacks     map[string]string
+	// localized holds the localized fields by content type
+	localized map[string]map[string]bool
+}
+
+// New returns a transport serving the snapshot. The snapshot holds the latest
+// version of every entity, which is served for published entities as well.
+func New(snapshot *transfer.Snapshot) (*Transport, error) {
+	t := &Transport{
+		defaultLocale: defaultLocale,
+		fallbacks:     map[string]string{},
+		localized:     map[string]map[string]bool{},
+	}
+	for _, section := range []struct {
+		dst *[]map[string]interface{}
+		src interface{}
+	}{
+		{&t.entries, snapshot.Entries},
+		{&t.assets, snapshot.Assets},
+		{&t.contentTypes, snapshot.ContentTypes},
+		{&t.locales, snapshot.Locales},
+		{&t.tags, snapshot.Tags},
+	} {
+		data, err := json.Marshal(section.src)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, section.dst); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, locale := range snapshot.Locales {
+		if locale.Default {
+			t.defaultLocale = locale.Code
+		}
+		if locale.FallbackCode != "" {
+			t.fallbacks[locale.Code] = locale.FallbackCode
+		}
+	}
+	for _, ct := range snapshot.ContentTypes {
+		if ct.Sys == nil {
+			continue
+		}
+		fields := map[string]bool{}
+		for _, field := range ct.Fields {
+			fields[field.ID] = field.Localized
+		}
+		t.localized[ct.Sys.ID] = fields
+	}
+
+	return t, nil
+}
+
+// Open returns a transport serving all export files of the directory
+func Open(dir string) (*Transport, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no export files in %s", dir)
+	}
+
+	var snapshot transfer.Snapshot
+	for _, file := range files {
+		s, err := readSnapshot(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		snapshot.ContentTypes = append(snapshot.ContentTypes, s.ContentTypes...)
+		snapshot.Tags = append(snapshot.Tags, s.Tags...)
+		snapshot.Entries = append(snapshot.Entries, s.Entries...)
+		snapshot.Assets = append(snapshot.Assets, s.Assets...)
+		snapshot.Locales = append(snapshot.Locales, s.Locales...)
+	}
+
+	return New(&snapshot)
+}
+
+func readSnapshot(file string) (*transfer.Snapshot, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return transfer.ReadSnapshot(f)
+}
+
+// Client returns a http client using the transport
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// RoundTrip answers the request from the snapshot
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	status, v := t.serve(req)
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/vnd.contentful.delivery.v1+json"}},
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
+
+// request is a parsed read request
+type request struct {
+	space       string
+	environment string
+	resource    string
+	id          string
+	preview     bool
+	// locale of the response, empty for all locales
+	locale string
+	query  map[string][]string
+}
+
+func (t *Transport) serve(req *http.Request) (int, interface{}) {
+	match := routeRegex.FindStringSubmatch(req.URL.Path)
+	if req.Method != http.MethodGet || match == nil {
+		return errorResponse(http.StatusNotFound, "NotFound", fmt.Sprintf("%s %s is not available offline", req.Method, req.URL.Path))
+	}
+
+	r := &request{
+		space:       match[1],
+		environment: match[2],
+		resource:    match[3],
+		id:          match[4],
+		preview:     t.Preview || strings.HasPrefix(req.URL.Hostname(), "preview."),
+		locale:      t.defaultLocale,
+		query:       req.URL.Query(),
+	}
+	if r.environment == "" {
+		r.environment = "master"
+	}
+	if locale := r.param("locale"); locale == "*" {
+		r.locale = ""
+	} else if locale != "" {
+		if !t.knownLocale(locale) {
+			return errorResponse(http.StatusBadRequest, "BadRequest", fmt.Sprintf("unknown locale: %s", locale))
+		}
+		r.locale = locale
+	}
+
+	items, kind := t.items(r)
+	if r.id != "" {
+		for _, item := range items {
+			if id(item) == r.id {
+				return http.StatusOK, t.render(item, kind, r, r.locale)
+			}
+		}
+		return errorResponse(http.StatusNotFound, "NotFound", "The resource could not be found.")
+	}
+
+	res, err := t.list(items, kind, r)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, "InvalidQuery", err.Error())
+	}
+
+	return http.StatusOK, res
+}
+
+// items returns the entities of the resource the request may see
+func (t *Transport) items(r *request) ([]map[string]interface{}, string) {
+	var items []map[string]interface{}
+	var kind string
+	switch r.resource {
+	case "entries":
+		items, kind = t.entries, "Entry"
+	case "assets":
+		items, kind = t.assets, "Asset"
+	case "content_types":
+		items, kind = t.contentTypes, "ContentType"
+	case "locales":
+		return t.locales, "Locale"
+	case "tags":
+		var tags []map[string]interface{}
+		for _, tag := range t.tags {
+			if sys(tag)["visibility"] != "private" {
+				tags = append(tags, tag)
+			}
+		}
+		return tags, "Tag"
+	}
+
+	var visible []map[string]interface{}
+	for _, item := range items {
+		if t.visible(item, r) {
+			visible = append(visible, item)
+		}
+	}
+
+	return visible, kind
+}
+
+// visible reports whether the item is delivered, archived items never are
+// and drafts only by the preview api
+func (t *Transport) visible(item map[string]interface{}, r *request) bool {
+	s := sys(item)
+	if s["archivedAt"] != nil || s["archivedVersion"] != nil {
+		return false
+	}
+
+	return r.preview || s["publishedVersion"] != nil
+}
+
+func (t *Transport) knownLocale(code string) bool {
+	if code == t.defaultLocale {
+		return true
+	}
+	for _, locale := range t.locales {
+		if locale["code"] == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// render returns the item as the delivery api does, with fields in the
+// locale or in all locales if it is empty
+func (t *Transport) render(item map[string]interface{}, kind string, r *request, locale string) map[string]interface{} {
+	s := sys(item)
+	out := map[string]interface{}{}
+	for key, value := range item {
+		if key != "sys" && key != "fields" {
+			out[key] = value
+		}
+	}
+
+	outSys := map[string]interface{}{
+		"id":          s["id"],
+		"type":        kind,
+		"space":       link("Space", r.space),
+		"environment": link("Environment", r.environment),
+	}
+	for _, key := range []string{"createdAt", "updatedAt"} {
+		if value, ok := s[key]; ok {
+			outSys[key] = value
+		}
+	}
+	if counter, ok := s["publishedCounter"]; ok {
+		outSys["revision"] = counter
+	}
+	contentType := contentTypeID(item)
+	if contentType != "" && kind != "ContentType" {
+		outSys["contentType"] = link("ContentType", contentType)
+	}
+	out["sys"] = outSys
+
+	// entries with only empty fields have none
+	fields, ok := item["fields"]
+	if !ok {
+		return out
+	}
+	if kind == "ContentType" {
+		out["fields"] = fields
+		return out
+	}
+	if locale == "" {
+		out["fields"] = fields
+		return out
+	}
+
+	outSys["locale"] = locale
+	localized := map[string]interface{}{}
+	fieldMap, _ := fields.(map[string]interface{})
+	for field, values := range fieldMap {
+		values, _ := values.(map[string]interface{})
+		code := locale
+		if known, ok := t.localized[contentType]; ok && !known[field] {
+			code = t.defaultLocale
+		}
+		if value, ok := t.resolve(values, code); ok {
+			localized[field] = value
+		}
+	}
+	out["fields"] = localized
+
+	return out
+}
+
+// resolve returns the value of the locale, following its fallbacks
+func (t *Transport) resolve(values map[string]interface{}, code string) (interface{}, bool) {
+	seen := map[string]bool{}
+	for code != "" && !seen[code] {
+		if value, ok := values[code]; ok {
+			return value, true
+		}
+		seen[code] = true
+		code = t.fallbacks[code]
+	}
+
+	return nil, false
+}
+
+func (r *request) param(name string) string {
+	if values := r.query[name]; len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func (r *request) intParam(name string, value, maximum int) (int, error) {
+	param := r.param(name)
+	if param == "" {
+		return value, nil
+	}
+	value, err := strconv.Atoi(param)
+	if err != nil || value < 0 || value > maximum {
+		return 0, fmt.Errorf("%s must be a number between 0 and %d", name, maximum)
+	}
+
+	return value, nil
+}
+
+func errorResponse(status int, id, message string) (int, interface{}) {
+	return status, map[string]interface{}{
+		"sys":     map[string]interface{}{"type": "Error", "id": id},
+		"message": message,
+	}
+}
+
+func link(linkType, id string) map[string]interface{} {
+	return map[string]interface{}{"sys": map[string]interface{}{"type": "Link", "linkType": linkType, "id": id}}
+}
+
+func sys(item map[string]interface{}) map[string]interface{} {
+	s, _ := item["sys"].(map[string]interface{})
+
+	return s
+}
+
+func id(item map[string]interface{}) string {
+	id, _ := sys(item)["id"].(string)
+
+	return id
+}
+
+func contentTypeID(item map[string]interface{}) string {
+	values := lookup(item, []string{"sys", "contentType", "sys", "id"})
+	if len(values) == 0 {
+		return ""
+	}
+	id, _ := values[0].(string)
+
+	return id
+}
@@ -0,0 +1,304 @@
+package offline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/foomo/contentful"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testExport = `{
+	"contentTypes": [
+		{"sys": {"id": "cat", "version": 2, "publishedVersion": 1}, "name": "Cat", "displayField": "name", "fields": [
+			{"id": "name", "name": "Name", "type": "Symbol", "localized": true},
+			{"id": "lives", "name": "Lives", "type": "Integer"},
+			{"id": "likes", "name": "Likes", "type": "Array", "items": {"type": "Symbol"}},
+			{"id": "friend", "name": "Friend", "type": "Link", "linkType": "Entry"},
+			{"id": "image", "name": "Image", "type": "Link", "linkType": "Asset"}
+		]}
+	],
+	"tags": [
+		{"sys": {"id": "cute", "type": "Tag", "visibility": "public"}, "name": "Cute"},
+		{"sys": {"id": "secret", "type": "Tag", "visibility": "private"}, "name": "Secret"}
+	],
+	"entries": [
+		{"metadata": {"tags": [{"sys": {"type": "Link", "linkType": "Tag", "id": "cute"}}]}, "sys": {"id": "happycat", "version": 3, "publishedVersion": 2, "contentType": {"sys": {"id": "cat"}}}, "fields": {"name": {"en-US": "Happy Cat", "de-DE": "Glückliche Katze"}, "lives": {"en-US": 9}, "likes": {"en-US": ["fish", "sun"]}, "friend": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "grumpycat"}}}, "image": {"en-US": {"sys": {"type": "Link", "linkType": "Asset", "id": "nyancat"}}}}},
+		{"sys": {"id": "grumpycat", "version": 2, "publishedVersion": 1, "contentType": {"sys": {"id": "cat"}}}, "fields": {"name": {"en-US": "Grumpy Cat"}, "lives": {"en-US": 1}, "friend": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "oldcat"}}}}},
+		{"sys": {"id": "oldcat", "version": 4, "publishedVersion": 3, "contentType": {"sys": {"id": "cat"}}}, "fields": {"name": {"en-US": "Old Cat"}, "lives": {"en-US": 3}, "likes": {"en-US": ["sleep"]}}},
+		{"sys": {"id": "draftcat", "version": 1, "contentType": {"sys": {"id": "cat"}}}, "fields": {"name": {"en-US": "Draft Cat"}}},
+		{"sys": {"id": "lostcat", "version": 5, "publishedVersion": 3, "archivedVersion": 4, "contentType": {"sys": {"id": "cat"}}}, "fields": {"name": {"en-US": "Lost Cat"}}}
+	],
+	"assets": [
+		{"sys": {"id": "nyancat", "version": 2, "publishedVersion": 1}, "fields": {"title": {"en-US": "Nyan Cat"}, "file": {"en-US": {"fileName": "nyancat.png", "contentType": "image/png", "url": "//images.example/nyancat.png"}}}}
+	],
+	"locales": [
+		{"sys": {"id": "en"}, "name": "English", "code": "en-US", "default": true},
+		{"sys": {"id": "ch"}, "name": "Swiss German", "code": "de-CH", "fallbackCode": "de-DE"},
+		{"sys": {"id": "de"}, "name": "German", "code": "de-DE", "fallbackCode": "en-US"}
+	]
+}`
+
+func testEnvironment(t *testing.T, api contentful.API) *contentful.EnvironmentClient {
+	t.Helper()
+
+	return exportEnvironment(t, api, testExport)
+}
+
+func exportEnvironment(t *testing.T, api contentful.API, export string) *contentful.EnvironmentClient {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "contentful-export-space-master.json"), []byte(export), 0o600))
+	transport, err := Open(dir)
+	require.NoError(t, err)
+
+	cda, err := contentful.New(api, "token", contentful.WithHTTPClient(transport.Client()), contentful.WithRetryPolicy(contentful.NoRetryPolicy()))
+	require.NoError(t, err)
+
+	return cda.Space("space").Environment("master")
+}
+
+func listEntries(t *testing.T, env *contentful.EnvironmentClient, query func(q *contentful.Query)) *contentful.Collection[contentful.Entry] {
+	t.Helper()
+
+	col := env.Entries.List(t.Context())
+	query(&col.Query)
+	col, err := col.Get()
+	require.NoError(t, err)
+
+	return col
+}
+
+func entryIDs(entries []contentful.Entry) []string {
+	ids := []string{}
+	for _, entry := range entries {
+		ids = append(ids, entry.Sys.ID)
+	}
+
+	return ids
+}
+
+func TestEntriesFilter(t *testing.T) {
+	env := testEnvironment(t, contentful.APICDA)
+
+	for name, test := range map[string]struct {
+		query func(q *contentful.Query)
+		ids   []string
+	}{
+		"published": {func(q *contentful.Query) {}, []string{"happycat", "grumpycat", "oldcat"}},
+		"equal": {func(q *contentful.Query) {
+			q.ContentType("cat").Equal("fields.name", "Grumpy Cat")
+		}, []string{"grumpycat"}},
+		"equal number": {func(q *contentful.Query) {
+			q.ContentType("cat").Equal("fields.lives", 9)
+		}, []string{"happycat"}},
+		"equal array": {func(q *contentful.Query) {
+			q.ContentType("cat").Equal("fields.likes", "sun")
+		}, []string{"happycat"}},
+		"link": {func(q *contentful.Query) {
+			q.ContentType("cat").Equal("fields.friend.sys.id", "oldcat")
+		}, []string{"grumpycat"}},
+		"in": {func(q *contentful.Query) {
+			q.In("sys.id", []string{"oldcat", "draftcat", "happycat"})
+		}, []string{"happycat", "oldcat"}},
+		"tags": {func(q *contentful.Query) {
+			q.In("metadata.tags.sys.id", []string{"cute"})
+		}, []string{"happycat"}},
+		"exists": {func(q *contentful.Query) {
+			q.ContentType("cat").Exists("fields.likes")
+		}, []string{"happycat", "oldcat"}},
+		"not exists": {func(q *contentful.Query) {
+			q.ContentType("cat").NotExists("fields.likes")
+		}, []string{"grumpycat"}},
+		"order": {func(q *contentful.Query) {
+			q.ContentType("cat").Order("fields.lives", false)
+		}, []string{"grumpycat", "oldcat", "happycat"}},
+		"order reverse": {func(q *contentful.Query) {
+			q.ContentType("cat").Order("fields.name", true)
+		}, []string{"oldcat", "happycat", "grumpycat"}},
+		"skip and limit": {func(q *contentful.Query) {
+			q.ContentType("cat").Order("fields.lives", true).Skip(1).Limit(1)
+		}, []string{"oldcat"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			col := listEntries(t, env, test.query)
+			assert.Equal(t, test.ids, entryIDs(col.Items))
+		})
+	}
+}
+
+func TestEntriesWithoutFields(t *testing.T) {
+	// entries whose optional fields are all empty have no fields
+	env := exportEnvironment(t, contentful.APICDA, `{
+		"contentTypes": [{"sys": {"id": "cat", "version": 2, "publishedVersion": 1}, "name": "Cat", "fields": [{"id": "name", "name": "Name", "type": "Symbol"}]}],
+		"entries": [{"sys": {"id": "anonymouscat", "version": 2, "publishedVersion": 1, "contentType": {"sys": {"id": "cat"}}}}]
+	}`)
+
+	col := listEntries(t, env, func(q *contentful.Query) { q.ContentType("cat") })
+	require.Len(t, col.Items, 1)
+	assert.Equal(t, "anonymouscat", col.Items[0].Sys.ID)
+	assert.Equal(t, "cat", col.Items[0].Sys.ContentType.Sys.ID)
+}
+
+func TestEntriesPages(t *testing.T) {
+	env := testEnvironment(t, contentful.APICDA)
+
+	col := env.Entries.List(t.Context())
+	col.Query.Limit(2)
+	var ids []string
+	pages := 0
+	for page, err := range col.Pages(t.Context()) {
+		require.NoError(t, err)
+		assert.Equal(t, 3, page.Total)
+		ids = append(ids, entryIDs(page.Items)...)
+		pages++
+	}
+	assert.Equal(t, 2, pages)
+	assert.Equal(t, []string{"happycat", "grumpycat", "oldcat"}, ids)
+}
+
+func TestEntriesLocale(t *testing.T) {
+	env := testEnvironment(t, contentful.APICDA)
+
+	for locale, name := range map[string]string{"en-US": "Happy Cat", "de-DE": "Glückliche Katze", "de-CH": "Glückliche Katze"} {
+		entry, err := env.Entries.Get(t.Context(), "happycat", locale)
+		require.NoError(t, err)
+		assert.Equal(t, locale, entry.Sys.Locale)
+		assert.Equal(t, name, entry.Fields["name"], "localized fields fall back")
+		assert.InDelta(t, float64(9), entry.Fields["lives"], 0, "fields which are not localized are delivered in every locale")
+	}
+
+	entry, err := env.Entries.Get(t.Context(), "grumpycat", "de-CH")
+	require.NoError(t, err)
+	assert.Equal(t, "Grumpy Cat", entry.Fields["name"], "falls back to the default locale")
+
+	entry, err = env.Entries.Get(t.Context(), "happycat", "*")
+	require.NoError(t, err)
+	assert.Empty(t, entry.Sys.Locale)
+	assert.Equal(t, map[string]interface{}{"en-US": "Happy Cat", "de-DE": "Glückliche Katze"}, entry.Fields["name"])
+
+	col := listEntries(t, env, func(q *contentful.Query) {
+		q.ContentType("cat").Locale("de-DE").Equal("fields.name", "Glückliche Katze")
+	})
+	assert.Equal(t, []string{"happycat"}, entryIDs(col.Items), "filters see the requested locale")
+
+	_, err = env.Entries.Get(t.Context(), "happycat", "fr-FR")
+	var errResponse contentful.ErrorResponse
+	require.ErrorAs(t, err, &errResponse)
+	assert.Equal(t, "BadRequest", errResponse.Sys.ID)
+}
+
+func TestEntriesInclude(t *testing.T) {
+	env := testEnvironment(t, contentful.APICDA)
+
+	col := listEntries(t, env, func(q *contentful.Query) {
+		q.SysID("happycat")
+	})
+	require.Len(t, col.Items, 1)
+	assert.Equal(t, []string{"grumpycat"}, entryIDs(derefEntries(col.Includes.Entry)))
+	require.Len(t, col.Includes.Asset, 1)
+	assert.Equal(t, "Nyan Cat", col.Includes.Asset[0].Fields.Title["en-US"])
+
+	col = listEntries(t, env, func(q *contentful.Query) {
+		q.SysID("happycat").Include(2)
+	})
+	assert.Equal(t, []string{"grumpycat", "oldcat"}, entryIDs(derefEntries(col.Includes.Entry)))
+
+	col = listEntries(t, env, func(q *contentful.Query) {
+		q.In("sys.id", []string{"happycat", "grumpycat"})
+	})
+	assert.Nil(t, col.Includes.GetEntry("grumpycat"), "items are not included")
+	assert.NotNil(t, col.Includes.GetEntry("oldcat"))
+}
+
+func TestEntriesSelect(t *testing.T) {
+	env := testEnvironment(t, contentful.APICDA)
+
+	col := listEntries(t, env, func(q *contentful.Query) {
+		q.ContentType("cat").SysID("happycat").Select([]string{"fields.name"})
+	})
+	require.Len(t, col.Items, 1)
+	assert.Equal(t, "happycat", col.Items[0].Sys.ID)
+	assert.Equal(t, map[string]interface{}{"name": "Happy Cat"}, col.Items[0].Fields)
+	assert.Nil(t, col.Items[0].Metadata)
+}
+
+func TestEntriesDrafts(t *testing.T) {
+	t.Run("delivery", func(t *testing.T) {
+		env := testEnvironment(t, contentful.APICDA)
+
+		for _, id := range []string{"draftcat", "lostcat"} {
+			_, err := env.Entries.Get(t.Context(), id)
+			require.ErrorAs(t, err, &contentful.NotFoundError{})
+		}
+	})
+
+	t.Run("preview", func(t *testing.T) {
+		env := testEnvironment(t, contentful.APICPA)
+
+		entry, err := env.Entries.Get(t.Context(), "draftcat")
+		require.NoError(t, err)
+		assert.Equal(t, "Draft Cat", entry.Fields["name"])
+
+		_, err = env.Entries.Get(t.Context(), "lostcat")
+		require.ErrorAs(t, err, &contentful.NotFoundError{})
+	})
+}
+
+func TestEntriesInvalidQuery(t *testing.T) {
+	env := testEnvironment(t, contentful.APICDA)
+
+	for name, query := range map[string]func(q *contentful.Query){
+		"operator":     func(q *contentful.Query) { q.ContentType("cat").Match("fields.name", "cat") },
+		"content type": func(q *contentful.Query) { q.Equal("fields.name", "Happy Cat") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			col := env.Entries.List(t.Context())
+			query(&col.Query)
+			_, err := col.Next()
+			var errResponse contentful.ErrorResponse
+			require.ErrorAs(t, err, &errResponse)
+			assert.Equal(t, "InvalidQuery", errResponse.Sys.ID)
+		})
+	}
+}
+
+func TestResources(t *testing.T) {
+	env := testEnvironment(t, contentful.APICDA)
+
+	assets := env.Assets.List(t.Context())
+	assets.Query.Locale("*")
+	_, err := assets.Next()
+	require.NoError(t, err)
+	require.Len(t, assets.Items, 1)
+	assert.Equal(t, "//images.example/nyancat.png", assets.Items[0].Fields.File["en-US"].URL)
+
+	ct, err := env.ContentTypes.Get(t.Context(), "cat")
+	require.NoError(t, err)
+	assert.Equal(t, "name", ct.DisplayField)
+	assert.Len(t, ct.Fields, 5)
+
+	locales, err := env.Locales.List(t.Context()).Next()
+	require.NoError(t, err)
+	assert.Equal(t, 3, locales.Total)
+
+	tags, err := env.Tags.List(t.Context()).Next()
+	require.NoError(t, err)
+	require.Len(t, tags.Items, 1, "private tags are not delivered")
+	assert.Equal(t, "Cute", tags.Items[0].Name)
+
+	_, err = env.Client().Entries.Sync(t.Context(), "space", true).Next()
+	require.ErrorAs(t, err, &contentful.NotFoundError{}, "sync is not available offline")
+}
+
+func derefEntries(entries []*contentful.Entry) []contentful.Entry {
+	out := make([]contentful.Entry, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, *entry)
+	}
+
+	return out
+}
@@ -0,0 +1,412 @@
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultLimit   = 100
+	maxLimit       = 1000
+	defaultInclude = 1
+	maxInclude     = 10
+)
+
+// reserved parameters which are not filters
+var reserved = map[string]bool{
+	"content_type": true,
+	"include":      true,
+	"limit":        true,
+	"locale":       true,
+	"order":        true,
+	"select":       true,
+	"skip":         true,
+}
+
+// filterRegex splits a filter parameter into path and operator
+var filterRegex = regexp.MustCompile(`^([a-zA-Z0-9_.]+?)(?:\[([a-z]+)\])?$`)
+
+// filter matches the values at a path of an item
+type filter struct {
+	path  []string
+	match func(values []interface{}) bool
+}
+
+// list evaluates the query of the request on the items
+func (t *Transport) list(items []map[string]interface{}, kind string, r *request) (map[string]interface{}, error) {
+	filters, err := r.filters(kind)
+	if err != nil {
+		return nil, err
+	}
+	skip, err := r.intParam("skip", 0, math.MaxInt)
+	if err != nil {
+		return nil, err
+	}
+	limit, err := r.intParam("limit", defaultLimit, maxLimit)
+	if err != nil {
+		return nil, err
+	}
+	include, err := r.intParam("include", defaultInclude, maxInclude)
+	if err != nil {
+		return nil, err
+	}
+
+	// filters and order see the fields in the requested locale, or in the
+	// default locale if all locales are requested
+	locale := r.locale
+	if locale == "" {
+		locale = t.defaultLocale
+	}
+
+	type candidate struct {
+		item map[string]interface{}
+		view map[string]interface{}
+	}
+	var matches []candidate
+	for _, item := range items {
+		view := t.render(item, kind, r, locale)
+		if matchAll(view, filters) {
+			matches = append(matches, candidate{item: item, view: view})
+		}
+	}
+
+	order, err := r.order(kind)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(matches, func(a, b candidate) int {
+		for _, key := range order {
+			c := compare(first(lookup(a.view, key.path)), first(lookup(b.view, key.path)))
+			if key.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+
+	total := len(matches)
+	matches = matches[min(skip, total):]
+	matches = matches[:min(limit, len(matches))]
+
+	selection, err := r.selection(kind)
+	if err != nil {
+		return nil, err
+	}
+	page := make([]map[string]interface{}, 0, len(matches))
+	for _, match := range matches {
+		page = append(page, selectFields(t.render(match.item, kind, r, r.locale), selection))
+	}
+
+	res := map[string]interface{}{
+		"sys":   map[string]interface{}{"type": "Array"},
+		"total": total,
+		"skip":  skip,
+		"limit": limit,
+		"items": page,
+	}
+	if kind == "Entry" && include > 0 {
+		if includes := t.includes(page, r, include); includes != nil {
+			res["includes"] = includes
+		}
+	}
+
+	return res, nil
+}
+
+// filters parses the filter parameters of the request. Equality, [in] and
+// [exists] are supported.
+func (r *request) filters(kind string) ([]filter, error) {
+	var filters []filter
+	if contentType := r.param("content_type"); contentType != "" {
+		filters = append(filters, equal("sys.contentType.sys.id", contentType))
+	}
+
+	for param, values := range r.query {
+		if reserved[param] {
+			continue
+		}
+		match := filterRegex.FindStringSubmatch(param)
+		if match == nil {
+			return nil, fmt.Errorf("invalid parameter %q", param)
+		}
+		if err := r.needsContentType(kind, match[1]); err != nil {
+			return nil, err
+		}
+
+		value := values[0]
+		switch match[2] {
+		case "":
+			filters = append(filters, equal(match[1], value))
+		case "in":
+			expected := strings.Split(value, ",")
+			filters = append(filters, filter{path: strings.Split(match[1], "."), match: func(values []interface{}) bool {
+				return slices.ContainsFunc(values, func(v interface{}) bool {
+					return slices.Contains(expected, format(v))
+				})
+			}})
+		case "exists":
+			exists, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be true or false", param)
+			}
+			filters = append(filters, filter{path: strings.Split(match[1], "."), match: func(values []interface{}) bool {
+				return (len(values) > 0) == exists
+			}})
+		default:
+			return nil, fmt.Errorf("operator [%s] of %q is not supported offline", match[2], param)
+		}
+	}
+
+	return filters, nil
+}
+
+// needsContentType fails for entry queries on fields without content type,
+// as the delivery api does
+func (r *request) needsContentType(kind, path string) error {
+	if kind == "Entry" && strings.HasPrefix(path, "fields.") && r.param("content_type") == "" {
+		return fmt.Errorf("querying %s requires a content_type", path)
+	}
+
+	return nil
+}
+
+type orderKey struct {
+	path []string
+	desc bool
+}
+
+func (r *request) order(kind string) ([]orderKey, error) {
+	var order []orderKey
+	for _, key := range strings.Split(r.param("order"), ",") {
+		if key == "" {
+			continue
+		}
+		desc := strings.HasPrefix(key, "-")
+		key = strings.TrimPrefix(key, "-")
+		if err := r.needsContentType(kind, key); err != nil {
+			return nil, err
+		}
+		order = append(order, orderKey{path: strings.Split(key, "."), desc: desc})
+	}
+
+	return order, nil
+}
+
+// selection returns the selected paths, at most two levels deep
+func (r *request) selection(kind string) ([][]string, error) {
+	var selection [][]string
+	for _, key := range strings.Split(r.param("select"), ",") {
+		if key == "" {
+			continue
+		}
+		path := strings.Split(key, ".")
+		if len(path) > 2 {
+			return nil, fmt.Errorf("select %s is deeper than two levels", key)
+		}
+		if err := r.needsContentType(kind, key); err != nil {
+			return nil, err
+		}
+		selection = append(selection, path)
+	}
+
+	return selection, nil
+}
+
+// selectFields keeps the sys and the selected paths of the item
+func selectFields(item map[string]interface{}, selection [][]string) map[string]interface{} {
+	if len(selection) == 0 {
+		return item
+	}
+
+	out := map[string]interface{}{"sys": item["sys"]}
+	for _, path := range selection {
+		value, ok := item[path[0]]
+		if !ok {
+			continue
+		}
+		if len(path) == 1 {
+			out[path[0]] = value
+			continue
+		}
+		nested, _ := value.(map[string]interface{})
+		if value, ok := nested[path[1]]; ok {
+			selected, _ := out[path[0]].(map[string]interface{})
+			if selected == nil {
+				selected = map[string]interface{}{}
+				out[path[0]] = selected
+			}
+			selected[path[1]] = value
+		}
+	}
+
+	return out
+}
+
+// includes resolves the entries and assets linked from the items up to the
+// depth. Entries which are items themselves are not included.
+func (t *Transport) includes(items []map[string]interface{}, r *request, depth int) map[string]interface{} {
+	seen := map[string]bool{}
+	for _, item := range items {
+		seen["Entry:"+id(item)] = true
+	}
+
+	included := map[string][]interface{}{}
+	level := items
+	for range depth {
+		var next []map[string]interface{}
+		for _, item := range level {
+			walkLinks(item["fields"], func(linkType, linkID string) {
+				key := linkType + ":" + linkID
+				if seen[key] {
+					return
+				}
+				seen[key] = true
+
+				source, kind := t.entries, "Entry"
+				if linkType == "Asset" {
+					source, kind = t.assets, "Asset"
+				}
+				for _, candidate := range source {
+					if id(candidate) == linkID && t.visible(candidate, r) {
+						linked := t.render(candidate, kind, r, r.locale)
+						included[kind] = append(included[kind], linked)
+						next = append(next, linked)
+						return
+					}
+				}
+			})
+		}
+		level = next
+	}
+
+	if len(included) == 0 {
+		return nil
+	}
+	includes := map[string]interface{}{}
+	for kind, items := range included {
+		includes[kind] = items
+	}
+
+	return includes
+}
+
+// walkLinks calls fn for every entry and asset link in v, rich text included
+func walkLinks(v interface{}, fn func(linkType, id string)) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if s, ok := v["sys"].(map[string]interface{}); ok && s["type"] == "Link" {
+			linkType, _ := s["linkType"].(string)
+			linkID, _ := s["id"].(string)
+			if linkType == "Entry" || linkType == "Asset" {
+				fn(linkType, linkID)
+			}
+			return
+		}
+		for _, value := range v {
+			walkLinks(value, fn)
+		}
+	case []interface{}:
+		for _, value := range v {
+			walkLinks(value, fn)
+		}
+	}
+}
+
+func equal(path, expected string) filter {
+	return filter{path: strings.Split(path, "."), match: func(values []interface{}) bool {
+		return slices.ContainsFunc(values, func(v interface{}) bool {
+			return format(v) == expected
+		})
+	}}
+}
+
+func matchAll(item map[string]interface{}, filters []filter) bool {
+	for _, f := range filters {
+		if !f.match(lookup(item, f.path)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookup returns the values at the path, arrays on the way are flattened
+func lookup(v interface{}, path []string) []interface{} {
+	if arr, ok := v.([]interface{}); ok {
+		var values []interface{}
+		for _, elem := range arr {
+			values = append(values, lookup(elem, path)...)
+		}
+		return values
+	}
+	if len(path) == 0 {
+		if v == nil {
+			return nil
+		}
+		return []interface{}{v}
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		if child, ok := m[path[0]]; ok {
+			return lookup(child, path[1:])
+		}
+	}
+
+	return nil
+}
+
+func first(values []interface{}) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return values[0]
+}
+
+// compare orders numbers by value and everything else by its string form,
+// missing values come first
+func compare(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	if x, ok := a.(float64); ok {
+		if y, ok := b.(float64); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+
+	return strings.Compare(format(a), format(b))
+}
+
+// format returns the query string form of a value
+func format(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	data, _ := json.Marshal(v)
+
+	return string(data)
+}
@@ -0,0 +1,328 @@
+package contentful
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// API identifies one of the Contentful APIs
+type API string
+
+const (
+	// APICMA Content Management API
+	APICMA API = "CMA"
+	// APICDA Content Delivery API
+	APICDA API = "CDA"
+	// APICPA Content Preview API
+	APICPA API = "CPA"
+)
+
+var idRegex = regexp.MustCompile(`^[a-zA-Z0-9_.\-]{1,64}$`)
+
+// Option configures a client created with New
+type Option func(*config)
+
+type config struct {
+	region       Region
+	environment  string
+	spaceID      string
+	organization string
+	baseURL      string
+	uploadURL    string
+	httpClient   *http.Client
+	timeout      time.Duration
+	application  string
+	integration  string
+	logger       *slog.Logger
+	retryPolicy  *RetryPolicy
+	rateLimiter  *RateLimiter
+	middlewares  []Middleware
+	hooks        []OperationHook
+	headers      map[string]string
+	queryParams  map[string]string
+	errs         []error
+}
+
+// WithRegion sets the region the client talks to
+func WithRegion(region Region) Option {
+	return func(cfg *config) {
+		cfg.region = region
+	}
+}
+
+// WithEnvironment sets the environment all space resources are read from
+func WithEnvironment(environment string) Option {
+	return func(cfg *config) {
+		cfg.environment = environment
+	}
+}
+
+// WithSpace sets the default space of the client
+func WithSpace(spaceID string) Option {
+	return func(cfg *config) {
+		cfg.spaceID = spaceID
+	}
+}
+
+// WithOrganization sends the X-Contentful-Organization header
+func WithOrganization(organizationID string) Option {
+	return func(cfg *config) {
+		cfg.organization = organizationID
+	}
+}
+
+// WithBaseURL overrides the api base url
+func WithBaseURL(baseURL string) Option {
+	return func(cfg *config) {
+		cfg.baseURL = baseURL
+	}
+}
+
+// WithUploadURL overrides the upload api base url
+func WithUploadURL(uploadURL string) Option {
+	return func(cfg *config) {
+		cfg.uploadURL = uploadURL
+	}
+}
+
+// WithHTTPClient sets the http client used to send requests
+func WithHTTPClient(client *http.Client) Option {
+	return func(cfg *config) {
+		cfg.httpClient = client
+	}
+}
+
+// WithTimeout sets the timeout of a single request attempt
+func WithTimeout(timeout time.Duration) Option {
+	return func(cfg *config) {
+		cfg.timeout = timeout
+	}
+}
+
+// WithApplication adds the app name and version to the X-Contentful-User-Agent header
+func WithApplication(name, version string) Option {
+	return func(cfg *config) {
+		cfg.application, cfg.errs = userAgentPart("app", name, version, cfg.errs)
+	}
+}
+
+// WithIntegration adds the integration name and version to the X-Contentful-User-Agent header
+func WithIntegration(name, version string) Option {
+	return func(cfg *config) {
+		cfg.integration, cfg.errs = userAgentPart("integration", name, version, cfg.errs)
+	}
+}
+
+// WithLogger sets the structured logger
+func WithLogger(logger *slog.Logger) Option {
+	return func(cfg *config) {
+		cfg.logger = logger
+	}
+}
+
+// WithRetryPolicy sets the retry policy
+func WithRetryPolicy(policy *RetryPolicy) Option {
+	return func(cfg *config) {
+		cfg.retryPolicy = policy
+	}
+}
+
+// WithRateLimiter throttles all requests through l
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(cfg *config) {
+		cfg.rateLimiter = l
+	}
+}
+
+// WithMiddleware appends middlewares to the request chain
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(cfg *config) {
+		cfg.middlewares = append(cfg.middlewares, middlewares...)
+	}
+}
+
+// WithOperationHook appends hooks called for every api operation
+func WithOperationHook(hooks ...OperationHook) Option {
+	return func(cfg *config) {
+		cfg.hooks = append(cfg.hooks, hooks...)
+	}
+}
+
+// WithHeader sends an additional header with every request
+func WithHeader(key, value string) Option {
+	return func(cfg *config) {
+		cfg.headers[key] = value
+	}
+}
+
+// WithQueryParam sends an additional query parameter with every request
+func WithQueryParam(key, value string) Option {
+	return func(cfg *config) {
+		cfg.queryParams[key] = value
+	}
+}
+
+// New returns a client for the given api. The configuration is validated and
+// copied, so the returned client can safely be shared between goroutines.
+func New(api API, token string, opts ...Option) (*Contentful, error) {
+	cfg := &config{
+		retryPolicy: DefaultRetryPolicy(),
+		headers:     map[string]string{},
+		queryParams: map[string]string{},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if err := cfg.validate(api, token); err != nil {
+		return nil, err
+	}
+
+	return newContentful(api, token, cfg), nil
+}
+
+func (cfg *config) validate(api API, token string) error {
+	errs := cfg.errs
+
+	switch api {
+	case APICMA, APICDA, APICPA:
+	default:
+		errs = append(errs, fmt.Errorf("unknown api %q", api))
+	}
+
+	if token == "" {
+		errs = append(errs, errors.New("token is required"))
+	}
+
+	if _, err := ParseRegion(string(cfg.region)); err != nil {
+		errs = append(errs, err)
+	}
+
+	if cfg.environment != "" && !idRegex.MatchString(cfg.environment) {
+		errs = append(errs, fmt.Errorf("invalid environment %q", cfg.environment))
+	}
+
+	if cfg.spaceID != "" && !idRegex.MatchString(cfg.spaceID) {
+		errs = append(errs, fmt.Errorf("invalid space %q", cfg.spaceID))
+	}
+
+	for name, value := range map[string]string{"base url": cfg.baseURL, "upload url": cfg.uploadURL} {
+		if value == "" {
+			continue
+		}
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("invalid %s %q", name, value))
+		}
+	}
+
+	if cfg.timeout < 0 {
+		errs = append(errs, fmt.Errorf("invalid timeout %s", cfg.timeout))
+	}
+
+	if cfg.retryPolicy == nil {
+		errs = append(errs, errors.New("retry policy must not be nil"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func newContentful(api API, token string, cfg *config) *Contentful {
+	client := http.DefaultClient
+	if cfg.httpClient != nil {
+		client = cfg.httpClient
+	}
+	if cfg.timeout > 0 {
+		// copy the client to not modify a shared instance
+		clone := *client
+		clone.Timeout = cfg.timeout
+		client = &clone
+	}
+
+	headers := map[string]string{
+		"Authorization":           "Bearer " + token,
+		"X-Contentful-User-Agent": userAgent(cfg.application, cfg.integration),
+	}
+
+	var baseURL, uploadURL string
+	switch api {
+	case APICMA:
+		headers["Content-Type"] = "application/vnd.contentful.management.v1+json"
+		baseURL = "https://api.contentful.com"
+		uploadURL = "https://upload.contentful.com"
+	case APICDA:
+		headers["Content-Type"] = "application/vnd.contentful.delivery.v1+json"
+		baseURL = "https://cdn.contentful.com"
+	case APICPA:
+		headers["Content-Type"] = "application/vnd.contentful.delivery.v1+json"
+		baseURL = "https://preview.contentful.com"
+	}
+
+	if cfg.organization != "" {
+		headers["X-Contentful-Organization"] = cfg.organization
+	}
+	maps.Copy(headers, cfg.headers)
+
+	c := &Contentful{
+		client:      client,
+		api:         string(api),
+		token:       token,
+		headers:     headers,
+		queryParams: maps.Clone(cfg.queryParams),
+		BaseURL:     baseURL,
+		UploadURL:   uploadURL,
+		Environment: cfg.environment,
+		spaceID:     cfg.spaceID,
+		retryPolicy: cfg.retryPolicy,
+		rateLimiter: cfg.rateLimiter,
+		middlewares: slices.Clone(cfg.middlewares),
+		hooks:       slices.Clone(cfg.hooks),
+		logger:      cfg.logger,
+	}
+	c.SetRegion(cfg.region)
+
+	if cfg.baseURL != "" {
+		c.BaseURL = cfg.baseURL
+	}
+	if cfg.uploadURL != "" {
+		c.UploadURL = cfg.uploadURL
+	}
+
+	c.wire()
+
+	return c
+}
+
+// userAgent builds the X-Contentful-User-Agent header value
+func userAgent(application, integration string) string {
+	parts := []string{
+		"sdk contentful.go/" + Version,
+		"platform go/" + strings.TrimPrefix(runtime.Version(), "go"),
+		"os " + runtime.GOOS,
+	}
+	if application != "" {
+		parts = append(parts, application)
+	}
+	if integration != "" {
+		parts = append(parts, integration)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func userAgentPart(kind, name, version string, errs []error) (string, []error) {
+	if name == "" || strings.ContainsAny(name+version, "; /") {
+		return "", append(errs, fmt.Errorf("invalid %s name %q or version %q", kind, name, version))
+	}
+	if version == "" {
+		return kind + " " + name, errs
+	}
+	return kind + " " + name + "/" + version, errs
+}
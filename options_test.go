@@ -0,0 +1,131 @@
+package contentful
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	httpClient := &http.Client{}
+	logger := slog.Default()
+	policy := NoRetryPolicy()
+	limiter := NewRateLimiter(1, 1)
+
+	c, err := New(APICMA, CMAToken,
+		WithRegion(RegionEU),
+		WithEnvironment("staging"),
+		WithSpace(spaceID),
+		WithOrganization(organizationID),
+		WithHTTPClient(httpClient),
+		WithTimeout(time.Second),
+		WithApplication("importer", "1.2.3"),
+		WithIntegration("ci", ""),
+		WithLogger(logger),
+		WithRetryPolicy(policy),
+		WithRateLimiter(limiter),
+		WithHeader("X-Custom", "value"),
+		WithQueryParam("foo", "bar"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://api.eu.contentful.com", c.BaseURL)
+	assert.Equal(t, "https://upload.eu.contentful.com", c.UploadURL)
+	assert.Equal(t, "staging", c.Environment)
+	assert.Equal(t, spaceID, c.SpaceID())
+	assert.Equal(t, time.Second, c.client.Timeout)
+	assert.Zero(t, httpClient.Timeout, "shared http client must not be modified")
+	assert.Same(t, logger, c.logger)
+	assert.Same(t, policy, c.retryPolicy)
+	assert.Same(t, limiter, c.rateLimiter)
+	assert.Nil(t, c.Headers)
+	assert.NotNil(t, c.Upload)
+
+	req, err := c.newRequest(t.Context(), http.MethodGet, "/spaces/id1/entries", nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer "+CMAToken, req.Header.Get("Authorization"))
+	assert.Equal(t, organizationID, req.Header.Get("X-Contentful-Organization"))
+	assert.Equal(t, "value", req.Header.Get("X-Custom"))
+	assert.Equal(t, "bar", req.URL.Query().Get("foo"))
+	assert.Equal(t, "https://api.eu.contentful.com/spaces/id1/entries?foo=bar", req.URL.String())
+
+	userAgent := req.Header.Get("X-Contentful-User-Agent")
+	assert.Equal(t, "sdk contentful.go/"+Version+
+		"; platform go/"+strings.TrimPrefix(runtime.Version(), "go")+
+		"; os "+runtime.GOOS+
+		"; app importer/1.2.3; integration ci", userAgent)
+}
+
+func TestNewAPIs(t *testing.T) {
+	tests := []struct {
+		api         API
+		baseURL     string
+		contentType string
+	}{
+		{APICMA, "https://api.contentful.com", "application/vnd.contentful.management.v1+json"},
+		{APICDA, "https://cdn.contentful.com", "application/vnd.contentful.delivery.v1+json"},
+		{APICPA, "https://preview.contentful.com", "application/vnd.contentful.delivery.v1+json"},
+	}
+	for _, test := range tests {
+		t.Run(string(test.api), func(t *testing.T) {
+			c, err := New(test.api, "token")
+			require.NoError(t, err)
+			assert.Equal(t, test.baseURL, c.BaseURL)
+			assert.Equal(t, test.contentType, c.headers["Content-Type"])
+			assert.NotNil(t, c.Upload)
+		})
+	}
+}
+
+func TestNewValidation(t *testing.T) {
+	_, err := New("XYZ", "",
+		WithRegion("mars"),
+		WithEnvironment("feature/branch"),
+		WithBaseURL("not a url"),
+		WithTimeout(-time.Second),
+		WithApplication("my;app", "1"),
+		WithRetryPolicy(nil),
+	)
+	require.Error(t, err)
+	for _, msg := range []string{
+		`unknown api "XYZ"`,
+		"token is required",
+		`unknown region "mars"`,
+		`invalid environment "feature/branch"`,
+		`invalid base url "not a url"`,
+		"invalid timeout -1s",
+		`invalid app name "my;app"`,
+		"retry policy must not be nil",
+	} {
+		assert.Contains(t, err.Error(), msg)
+	}
+}
+
+func TestNewConcurrentUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(readTestData(t, "space-1.json")))
+	}))
+	defer server.Close()
+
+	c, err := New(APICMA, CMAToken, WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	for range 10 {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			_, err := c.Spaces.Get(t.Context(), "id1")
+			assert.NoError(t, err)
+		}()
+	}
+	for range 10 {
+		<-done
+	}
+}
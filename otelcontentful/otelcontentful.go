@@ -0,0 +1,287 @@
+// Package otelcontentful provides OpenTelemetry tracing and metrics for the
+// contentful client. Every api operation is traced by a span, with a child
+// span per attempt sending its request.
+//
+//	cma := contentful.NewCMA(token)
+//	otelcontentful.Instrument(cma)
+package otelcontentful
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/foomo/contentful"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope name
+const ScopeName = "github.com/foomo/contentful/otelcontentful"
+
+// Attribute keys set on spans and metrics
+const (
+	ServiceKey     = attribute.Key("contentful.service")
+	OperationKey   = attribute.Key("contentful.operation")
+	SpaceIDKey     = attribute.Key("contentful.space_id")
+	EnvironmentKey = attribute.Key("contentful.environment")
+	EntityIDKey    = attribute.Key("contentful.entity_id")
+	RequestIDKey   = attribute.Key("contentful.request_id")
+	ErrorIDKey     = attribute.Key("contentful.error_id")
+	WindowKey      = attribute.Key("contentful.ratelimit.window")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	propagators    propagation.TextMapPropagator
+}
+
+// Option configures the instrumentation
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider, defaults to the global one
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the meter provider, defaults to the global one
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// WithPropagators sets the propagators injecting the trace context into the
+// outgoing requests, defaults to the global one
+func WithPropagators(propagators propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = propagators
+	}
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+		propagators:    otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+func (c config) tracer() trace.Tracer {
+	return c.tracerProvider.Tracer(ScopeName, trace.WithInstrumentationVersion(contentful.Version))
+}
+
+// Instrument traces and measures all api operations of the client, using
+// the hook and the middleware of the package
+func Instrument(c *contentful.Contentful, opts ...Option) *contentful.Contentful {
+	return c.OnOperation(OperationHook(opts...)).Use(Middleware(opts...))
+}
+
+type operationKey struct{}
+
+// operation is the state of a traced operation shared by its attempts
+type operation struct {
+	attempts int
+}
+
+// OperationHook returns a contentful operation hook creating a span per api
+// operation, e.g. "Entries.Upsert". The spans of the middleware become its
+// children, one per attempt.
+func OperationHook(opts ...Option) contentful.OperationHook {
+	tracer := newConfig(opts).tracer()
+
+	return func(ctx context.Context, op contentful.Operation) (context.Context, func(err error)) {
+		attrs := operationAttributes(op)
+		if op.EntityID != "" {
+			attrs = append(attrs, EntityIDKey.String(op.EntityID))
+		}
+		ctx, span := tracer.Start(ctx, op.String(), trace.WithAttributes(attrs...))
+		ctx = context.WithValue(ctx, operationKey{}, &operation{})
+
+		return ctx, func(err error) {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}
+	}
+}
+
+func operationAttributes(op contentful.Operation) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		ServiceKey.String(op.Service),
+		OperationKey.String(op.String()),
+		SpaceIDKey.String(op.SpaceID),
+		EnvironmentKey.String(op.Environment),
+	}
+}
+
+type instrumentation struct {
+	next        contentful.Doer
+	tracer      trace.Tracer
+	propagators propagation.TextMapPropagator
+	duration    metric.Float64Histogram
+	errors      metric.Int64Counter
+	rateLimited metric.Int64Counter
+	remaining   metric.Int64Gauge
+}
+
+// Middleware returns a contentful middleware creating a span per request
+// and recording latency, error and rate limit metrics. The spans are named
+// after the http method and are children of the operation span if the
+// OperationHook is used, otherwise they are named after the operation.
+func Middleware(opts ...Option) contentful.Middleware {
+	cfg := newConfig(opts)
+
+	meter := cfg.meterProvider.Meter(ScopeName, metric.WithInstrumentationVersion(contentful.Version))
+
+	// instrument creation only fails for invalid names
+	duration, _ := meter.Float64Histogram("contentful.client.request.duration",
+		metric.WithDescription("Duration of contentful api requests"),
+		metric.WithUnit("s"),
+	)
+	errs, _ := meter.Int64Counter("contentful.client.request.errors",
+		metric.WithDescription("Number of failed contentful api requests by error type"),
+	)
+	rateLimited, _ := meter.Int64Counter("contentful.client.ratelimited",
+		metric.WithDescription("Number of rate limited contentful api requests"),
+	)
+	remaining, _ := meter.Int64Gauge("contentful.client.ratelimit.remaining",
+		metric.WithDescription("Remaining requests as reported by the rate limit headers"),
+	)
+
+	return func(next contentful.Doer) contentful.Doer {
+		return &instrumentation{
+			next:        next,
+			tracer:      cfg.tracer(),
+			propagators: cfg.propagators,
+			duration:    duration,
+			errors:      errs,
+			rateLimited: rateLimited,
+			remaining:   remaining,
+		}
+	}
+}
+
+// Do implements contentful.Doer
+func (i *instrumentation) Do(req *http.Request) (*http.Response, error) {
+	name := "Contentful " + req.Method
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	spanAttrs := []attribute.KeyValue{semconv.URLPath(req.URL.Path)}
+	if op, ok := contentful.OperationFromContext(req.Context()); ok {
+		name = op.String()
+		attrs = append(attrs, operationAttributes(op)...)
+		if op.EntityID != "" {
+			spanAttrs = append(spanAttrs, EntityIDKey.String(op.EntityID))
+		}
+	}
+	if state, ok := req.Context().Value(operationKey{}).(*operation); ok {
+		// the operation span carries the operation, attempts are named
+		// after the method as http client spans are
+		name = req.Method
+		if state.attempts > 0 {
+			spanAttrs = append(spanAttrs, semconv.HTTPRequestResendCount(state.attempts))
+		}
+		state.attempts++
+	}
+
+	ctx, span := i.tracer.Start(req.Context(), name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(spanAttrs...),
+	)
+	defer span.End()
+
+	// the clone keeps the headers of the caller free of the trace context
+	req = req.Clone(ctx)
+	i.propagators.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	start := time.Now()
+	res, err := i.next.Do(req)
+	elapsed := time.Since(start).Seconds()
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		errAttrs := append(attrs, semconv.ErrorTypeKey.String("network"))
+		i.duration.Record(ctx, elapsed, metric.WithAttributes(errAttrs...))
+		i.errors.Add(ctx, 1, metric.WithAttributes(errAttrs...))
+		return res, err
+	}
+
+	attrs = append(attrs, semconv.HTTPResponseStatusCode(res.StatusCode))
+	span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+	if requestID := res.Header.Get("X-Contentful-Request-Id"); requestID != "" {
+		span.SetAttributes(RequestIDKey.String(requestID))
+	}
+
+	for window, header := range map[string]string{
+		"second": "X-Contentful-Ratelimit-Second-Remaining",
+		"hour":   "X-Contentful-Ratelimit-Hour-Remaining",
+	} {
+		if value, errParse := strconv.ParseInt(res.Header.Get(header), 10, 64); errParse == nil {
+			i.remaining.Record(ctx, value, metric.WithAttributes(WindowKey.String(window)))
+		}
+	}
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		i.rateLimited.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		errorID := peekErrorID(res)
+		if errorID == "" {
+			errorID = strconv.Itoa(res.StatusCode)
+		}
+		span.SetAttributes(ErrorIDKey.String(errorID))
+		span.SetStatus(codes.Error, errorID)
+		attrs = append(attrs, semconv.ErrorTypeKey.String(errorID))
+		i.errors.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+
+	i.duration.Record(ctx, elapsed, metric.WithAttributes(attrs...))
+
+	return res, nil
+}
+
+// peekErrorID reads the sys.id of an error response and restores the body
+func peekErrorID(res *http.Response) string {
+	if res.Body == nil {
+		return ""
+	}
+
+	data, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+
+	var e contentful.ErrorResponse
+	if err := json.Unmarshal(data, &e); err != nil || e.Sys == nil {
+		return ""
+	}
+
+	return e.Sys.ID
+}
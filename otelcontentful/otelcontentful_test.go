@@ -0,0 +1,216 @@
+package otelcontentful
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/foomo/contentful"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+)
+
+func setup(t *testing.T, handler http.HandlerFunc) (*contentful.Contentful, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+
+	cma, recorder, reader, opts := setupWithoutInstrumentation(t, handler)
+	Instrument(cma, opts...)
+
+	return cma, recorder, reader
+}
+
+func setupWithoutInstrumentation(t *testing.T, handler http.HandlerFunc) (*contentful.Contentful, *tracetest.SpanRecorder, *sdkmetric.ManualReader, []Option) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	cma := contentful.NewCMA("token").SetRetryPolicy(contentful.NoRetryPolicy())
+	cma.BaseURL = server.URL
+
+	return cma, recorder, reader, []Option{
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		WithPropagators(propagation.TraceContext{}),
+	}
+}
+
+func assertAttributes(t *testing.T, span sdktrace.ReadOnlySpan, want map[attribute.Key]string) {
+	t.Helper()
+
+	attrs := attribute.NewSet(span.Attributes()...)
+	for key, want := range want {
+		value, ok := attrs.Value(key)
+		require.True(t, ok, key)
+		assert.Equal(t, want, value.AsString(), key)
+	}
+}
+
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	metrics := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func TestMiddlewareSpan(t *testing.T) {
+	var traceparent string
+	cma, recorder, reader := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.Header().Set("X-Contentful-Request-Id", "request-id")
+		w.Header().Set("X-Contentful-Ratelimit-Second-Remaining", "6")
+		_, _ = w.Write([]byte(`{"sys":{"type":"Entry","id":"nyancat"}}`))
+	})
+	cma.Environment = "staging"
+
+	_, err := cma.Entries.Get(t.Context(), "space", "nyancat")
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	attempt, span := spans[0], spans[1]
+	assert.Equal(t, "Entries.Get", span.Name())
+	assert.Equal(t, "GET", attempt.Name())
+	assert.Equal(t, span.SpanContext(), attempt.Parent())
+	assert.NotEmpty(t, traceparent)
+	assert.Contains(t, traceparent, attempt.SpanContext().SpanID().String())
+
+	assertAttributes(t, span, map[attribute.Key]string{
+		ServiceKey:     "Entries",
+		SpaceIDKey:     "space",
+		EnvironmentKey: "staging",
+		EntityIDKey:    "nyancat",
+	})
+	assertAttributes(t, attempt, map[attribute.Key]string{
+		ServiceKey:   "Entries",
+		EntityIDKey:  "nyancat",
+		RequestIDKey: "request-id",
+	})
+
+	metrics := collect(t, reader)
+	require.Contains(t, metrics, "contentful.client.request.duration")
+	gauge, ok := metrics["contentful.client.ratelimit.remaining"].(metricdata.Gauge[int64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 1)
+	assert.Equal(t, int64(6), gauge.DataPoints[0].Value)
+}
+
+func TestMiddlewareErrors(t *testing.T) {
+	cma, recorder, reader := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"sys":{"type":"Error","id":"RateLimitExceeded"},"message":"slow down"}`))
+	})
+
+	_, err := cma.Entries.Get(t.Context(), "space", "nyancat")
+	var rateLimitExceededError contentful.RateLimitExceededError
+	require.ErrorAs(t, err, &rateLimitExceededError)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assertAttributes(t, spans[0], map[attribute.Key]string{ErrorIDKey: "RateLimitExceeded"})
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+
+	metrics := collect(t, reader)
+	errs, ok := metrics["contentful.client.request.errors"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, errs.DataPoints, 1)
+	errAttrs := errs.DataPoints[0].Attributes
+	errorType, ok := errAttrs.Value("error.type")
+	require.True(t, ok)
+	assert.Equal(t, "RateLimitExceeded", errorType.AsString())
+
+	rateLimited, ok := metrics["contentful.client.ratelimited"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, rateLimited.DataPoints, 1)
+	assert.Equal(t, int64(1), rateLimited.DataPoints[0].Value)
+}
+
+func TestInstrumentRetries(t *testing.T) {
+	var requests int
+	cma, recorder, _ := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"sys":{"type":"Error","id":"ServiceUnavailable"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"sys":{"type":"Entry","id":"nyancat"}}`))
+	})
+	policy := contentful.DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+	cma.SetRetryPolicy(policy)
+
+	_, err := cma.Entries.Get(t.Context(), "space", "nyancat")
+	require.NoError(t, err)
+
+	// one operation span with a child span per attempt
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	operation := spans[2]
+	assert.Equal(t, "Entries.Get", operation.Name())
+	assert.Equal(t, codes.Unset, operation.Status().Code)
+	for i, attempt := range spans[:2] {
+		assert.Equal(t, "GET", attempt.Name())
+		assert.Equal(t, operation.SpanContext(), attempt.Parent())
+		attrs := attribute.NewSet(attempt.Attributes()...)
+		_, resent := attrs.Value(semconv.HTTPRequestResendCountKey)
+		assert.Equal(t, i > 0, resent)
+	}
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestMiddlewareWithoutHook(t *testing.T) {
+	cma, recorder, _, opts := setupWithoutInstrumentation(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"sys":{"type":"Entry","id":"nyancat"}}`))
+	})
+	cma.Use(Middleware(opts...))
+
+	_, err := cma.Entries.Get(t.Context(), "space", "nyancat")
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "Entries.Get", spans[0].Name())
+	assert.False(t, spans[0].Parent().IsValid())
+}
+
+func TestMiddlewareKeepsHeaders(t *testing.T) {
+	var traceparent string
+	cma, _, _, opts := setupWithoutInstrumentation(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		_, _ = w.Write([]byte(`{"sys":{"type":"Entry","id":"nyancat"}}`))
+	})
+	cma.Use(func(next contentful.Doer) contentful.Doer {
+		return contentful.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			res, err := next.Do(req)
+			assert.Empty(t, req.Header.Get("Traceparent"), "the request of the caller is not changed")
+			return res, err
+		})
+	})
+	Instrument(cma, opts...)
+
+	_, err := cma.Entries.Get(t.Context(), "space", "nyancat")
+	require.NoError(t, err)
+	assert.NotEmpty(t, traceparent)
+}
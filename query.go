@@ -1,8 +1,10 @@
 package contentful
 
 import (
+	"maps"
 	"net/url"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -245,6 +247,29 @@ func (q *Query) SyncToken(syncToken string) *Query {
 	return q
 }
 
+// Clone returns a copy of the query which can be changed independently
+func (q *Query) Clone() *Query {
+	c := *q
+	c.fields = slices.Clone(q.fields)
+	c.e = maps.Clone(q.e)
+	c.ne = maps.Clone(q.ne)
+	c.all = maps.Clone(q.all)
+	c.in = maps.Clone(q.in)
+	c.nin = maps.Clone(q.nin)
+	c.exists = slices.Clone(q.exists)
+	c.notExists = slices.Clone(q.notExists)
+	c.lt = maps.Clone(q.lt)
+	c.lte = maps.Clone(q.lte)
+	c.gt = maps.Clone(q.gt)
+	c.gte = maps.Clone(q.gte)
+	c.match = maps.Clone(q.match)
+	c.near = maps.Clone(q.near)
+	c.within = maps.Clone(q.within)
+	c.order = slices.Clone(q.order)
+
+	return &c
+}
+
 // Values constructs url.Values
 func (q *Query) Values() url.Values {
 	params := url.Values{}
@@ -294,3 +294,11 @@ func TestQuery(t *testing.T) {
 
 	assert.Equal(t, expected.Encode(), q.String())
 }
+
+func TestQueryClone(t *testing.T) {
+	q := NewQuery().Equal("fields.name", "cat").Order("sys.id", false)
+	c := q.Clone().In("sys.id", []string{"nyancat"}).Order("fields.name", true).Limit(10)
+
+	assert.Equal(t, "fields.name=cat&order=sys.id", q.String())
+	assert.Equal(t, "fields.name=cat&limit=10&order=sys.id%2C-fields.name&sys.id%5Bin%5D=nyancat", c.String())
+}
@@ -0,0 +1,163 @@
+package contentful
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// CMARequestsPerSecond default request rate of the Content Management API
+	CMARequestsPerSecond = 7
+
+	// CDARequestsPerSecond default request rate of the Content Delivery and Preview API
+	CDARequestsPerSecond = 55
+)
+
+// RateLimiter is a token bucket throttling outgoing requests. A single
+// limiter can be shared by many goroutines and clients.
+type RateLimiter struct {
+	// OnWait is called whenever a request had to wait for a token
+	OnWait func(wait time.Duration)
+
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	requests  atomic.Int64
+	throttled atomic.Int64
+	waitTime  atomic.Int64
+}
+
+// RateLimiterStats model
+type RateLimiterStats struct {
+	// Requests is the number of requests which passed the limiter
+	Requests int64
+	// Throttled is the number of requests which had to wait
+	Throttled int64
+	// WaitTime is the total time requests spent waiting
+	WaitTime time.Duration
+}
+
+var (
+	sharedRateLimitersMu sync.Mutex
+	sharedRateLimiters   = map[string]*RateLimiter{}
+)
+
+// NewRateLimiter returns a limiter allowing requestsPerSecond requests with
+// bursts of up to burst requests
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// SharedRateLimiter returns the process wide limiter registered for key,
+// creating it on first use. Use a key such as "CMA/<space id>" to share one
+// budget between all clients talking to the same space. If the limiter exists
+// with a higher rate or burst, it is lowered to the given ones, so the shared
+// budget does not exceed the budget of any of its users.
+func SharedRateLimiter(key string, requestsPerSecond float64, burst int) *RateLimiter {
+	sharedRateLimitersMu.Lock()
+	defer sharedRateLimitersMu.Unlock()
+
+	if l, ok := sharedRateLimiters[key]; ok {
+		l.restrict(requestsPerSecond, burst)
+		return l
+	}
+
+	l := NewRateLimiter(requestsPerSecond, burst)
+	sharedRateLimiters[key] = l
+	return l
+}
+
+// SetRateLimiter throttles all requests of the client through l
+func (c *Contentful) SetRateLimiter(l *RateLimiter) *Contentful {
+	c.rateLimiter = l
+	return c
+}
+
+// Wait blocks until a request may be sent or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	wait := l.reserve()
+	l.requests.Add(1)
+	if wait <= 0 {
+		return nil
+	}
+
+	l.throttled.Add(1)
+	l.waitTime.Add(int64(wait))
+	if l.OnWait != nil {
+		l.OnWait(wait)
+	}
+
+	if err := sleep(ctx, wait); err != nil {
+		l.cancel()
+		return err
+	}
+
+	return nil
+}
+
+// Stats returns the limiter metrics
+func (l *RateLimiter) Stats() RateLimiterStats {
+	return RateLimiterStats{
+		Requests:  l.requests.Load(),
+		Throttled: l.throttled.Load(),
+		WaitTime:  time.Duration(l.waitTime.Load()),
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait for it
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate <= 0 {
+		return 0
+	}
+
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// restrict lowers the rate and burst of the limiter, a rate of 0 or less is
+// unlimited
+func (l *RateLimiter) restrict(requestsPerSecond float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if requestsPerSecond > 0 && (l.rate <= 0 || requestsPerSecond < l.rate) {
+		l.rate = requestsPerSecond
+	}
+	if b := float64(max(burst, 1)); b < l.burst {
+		l.burst = b
+		l.tokens = min(l.tokens, b)
+	}
+}
+
+// cancel returns a reserved token
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = min(l.burst, l.tokens+1)
+}
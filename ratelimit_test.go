@@ -0,0 +1,100 @@
+package contentful
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(20, 2)
+
+	start := time.Now()
+	for range 6 {
+		require.NoError(t, l.Wait(t.Context()))
+	}
+
+	// 2 burst tokens, 4 more at 20/s
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+
+	stats := l.Stats()
+	assert.Equal(t, int64(6), stats.Requests)
+	assert.Equal(t, int64(4), stats.Throttled)
+	assert.Positive(t, stats.WaitTime)
+}
+
+func TestRateLimiterWaitContext(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	require.NoError(t, l.Wait(t.Context()))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	require.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestSharedRateLimiter(t *testing.T) {
+	a := SharedRateLimiter("CMA/shared-test", CMARequestsPerSecond, 1)
+	b := SharedRateLimiter("CMA/shared-test", CDARequestsPerSecond, 10)
+	assert.Same(t, a, b)
+	assert.NotSame(t, a, SharedRateLimiter("CDA/shared-test", CDARequestsPerSecond, 1))
+}
+
+func TestSharedRateLimiterRestrict(t *testing.T) {
+	l := SharedRateLimiter("CMA/restrict-test", CDARequestsPerSecond, 10)
+
+	// higher settings keep the limiter as it is
+	SharedRateLimiter("CMA/restrict-test", 100, 20)
+	assert.InDelta(t, float64(CDARequestsPerSecond), l.rate, 0)
+	assert.InDelta(t, float64(10), l.burst, 0)
+
+	// lower settings lower the shared budget
+	SharedRateLimiter("CMA/restrict-test", CMARequestsPerSecond, 2)
+	assert.InDelta(t, float64(CMARequestsPerSecond), l.rate, 0)
+	assert.InDelta(t, float64(2), l.burst, 0)
+	assert.LessOrEqual(t, l.tokens, float64(2))
+
+	// an unlimited rate does not lift the limit
+	SharedRateLimiter("CMA/restrict-test", 0, 2)
+	assert.InDelta(t, float64(CMARequestsPerSecond), l.rate, 0)
+}
+
+func TestRateLimiterClients(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(readTestData(t, "space-1.json")))
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	var mu sync.Mutex
+	l := NewRateLimiter(50, 1)
+	l.OnWait = func(wait time.Duration) {
+		mu.Lock()
+		waits = append(waits, wait)
+		mu.Unlock()
+	}
+
+	var wg sync.WaitGroup
+	for range 2 {
+		cma := NewCMA(CMAToken).SetRateLimiter(l)
+		cma.BaseURL = server.URL
+		for range 3 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := cma.Spaces.Get(t.Context(), "id1")
+				assert.NoError(t, err)
+			}()
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(6), l.Stats().Requests)
+	assert.Len(t, waits, 5)
+}
@@ -0,0 +1,162 @@
+package contentful
+
+import (
+	"maps"
+	"slices"
+)
+
+// LinkResolver replaces links in entry fields with the linked entries and
+// assets. Links are found in field values, arrays and rich text nodes.
+type LinkResolver struct {
+	entries    map[string]*Entry
+	assets     map[string]*Asset
+	unresolved map[string]*Link
+}
+
+// NewLinkResolver returns a resolver for the given entries and assets.
+// Entries and assets without sys can't be linked and are skipped.
+func NewLinkResolver(entries []*Entry, assets []*Asset) *LinkResolver {
+	r := &LinkResolver{
+		entries:    map[string]*Entry{},
+		assets:     map[string]*Asset{},
+		unresolved: map[string]*Link{},
+	}
+	for _, entry := range entries {
+		if entry != nil && entry.Sys != nil {
+			r.entries[entry.Sys.ID] = entry
+		}
+	}
+	for _, asset := range assets {
+		if asset != nil && asset.Sys != nil {
+			r.assets[asset.Sys.ID] = asset
+		}
+	}
+
+	return r
+}
+
+// ResolveLinks returns the items of the collection with their links resolved
+// up to depth levels using the items and the includes of the collection. The
+// second result lists the links which could not be resolved, including those
+// reported as notResolvable in the errors of the collection.
+func ResolveLinks(col *Collection[Entry], depth int) ([]*Entry, []*Link) {
+	entries := make([]*Entry, 0, len(col.Items))
+	for i := range col.Items {
+		entries = append(entries, &col.Items[i])
+	}
+
+	var assets []*Asset
+	if col.Includes != nil {
+		entries = append(entries, col.Includes.Entry...)
+		assets = col.Includes.Asset
+	}
+
+	r := NewLinkResolver(entries, assets)
+	for _, e := range col.Errors {
+		if e.Sys != nil && e.Sys.ID == "notResolvable" && e.Details["type"] == "Link" {
+			r.unresolved[e.Details["linkType"]+":"+e.Details["id"]] = NewLink(e.Details["linkType"], e.Details["id"])
+		}
+	}
+
+	resolved := make([]*Entry, 0, len(col.Items))
+	for _, entry := range entries[:len(col.Items)] {
+		resolved = append(resolved, r.ResolveEntry(entry, depth))
+	}
+
+	return resolved, r.Unresolved()
+}
+
+// ResolveEntry returns a copy of the entry with the links in its fields
+// replaced by *Entry and *Asset values up to depth levels. The entry itself
+// is not modified. Links back to an entry which is being resolved are kept
+// to avoid cycles.
+func (r *LinkResolver) ResolveEntry(entry *Entry, depth int) *Entry {
+	return r.resolveEntry(entry, depth, nil)
+}
+
+// Unresolved returns the links which could not be resolved ordered by type and id
+func (r *LinkResolver) Unresolved() []*Link {
+	links := make([]*Link, 0, len(r.unresolved))
+	for _, key := range slices.Sorted(maps.Keys(r.unresolved)) {
+		links = append(links, r.unresolved[key])
+	}
+
+	return links
+}
+
+func (r *LinkResolver) resolveEntry(entry *Entry, depth int, path []string) *Entry {
+	resolved := *entry
+	if depth <= 0 || entry.Fields == nil {
+		return &resolved
+	}
+
+	if entry.Sys != nil {
+		path = append(path, entry.Sys.ID)
+	}
+	resolved.Fields = make(map[string]interface{}, len(entry.Fields))
+	for key, value := range entry.Fields {
+		resolved.Fields[key] = r.resolveValue(value, depth, path)
+	}
+
+	return &resolved
+}
+
+// resolveValue returns a copy of value with all links replaced
+func (r *LinkResolver) resolveValue(value interface{}, depth int, path []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if link, ok := linkOf(v); ok {
+			return r.resolveLink(v, link, depth, path)
+		}
+		resolved := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolved[key] = r.resolveValue(item, depth, path)
+		}
+		return resolved
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, item := range v {
+			resolved[i] = r.resolveValue(item, depth, path)
+		}
+		return resolved
+	default:
+		return value
+	}
+}
+
+func (r *LinkResolver) resolveLink(value map[string]interface{}, link *Link, depth int, path []string) interface{} {
+	switch link.Sys.LinkType {
+	case "Entry":
+		entry, ok := r.entries[link.Sys.ID]
+		if !ok {
+			r.unresolved["Entry:"+link.Sys.ID] = link
+			return value
+		}
+		if slices.Contains(path, link.Sys.ID) {
+			return value
+		}
+		return r.resolveEntry(entry, depth-1, path)
+	case "Asset":
+		asset, ok := r.assets[link.Sys.ID]
+		if !ok {
+			r.unresolved["Asset:"+link.Sys.ID] = link
+			return value
+		}
+		return asset
+	default:
+		return value
+	}
+}
+
+// linkOf returns the link if value is a {"sys": {"type": "Link"}} object
+func linkOf(value map[string]interface{}) (*Link, bool) {
+	sys, ok := value["sys"].(map[string]interface{})
+	if !ok || sys["type"] != "Link" {
+		return nil, false
+	}
+
+	linkType, _ := sys["linkType"].(string)
+	id, _ := sys["id"].(string)
+
+	return NewLink(linkType, id), true
+}
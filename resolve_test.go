@@ -0,0 +1,108 @@
+package contentful
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const resolveCollectionJSON = `{
+	"sys": {"type": "Array"},
+	"total": 1,
+	"items": [{
+		"sys": {"id": "post", "type": "Entry"},
+		"fields": {
+			"author": {"sys": {"type": "Link", "linkType": "Entry", "id": "author"}},
+			"related": [
+				{"sys": {"type": "Link", "linkType": "Entry", "id": "other"}},
+				{"sys": {"type": "Link", "linkType": "Entry", "id": "missing"}}
+			],
+			"body": {
+				"nodeType": "document",
+				"content": [
+					{"nodeType": "embedded-asset-block", "data": {"target": {"sys": {"type": "Link", "linkType": "Asset", "id": "photo"}}}, "content": []}
+				]
+			}
+		}
+	}],
+	"includes": {
+		"Entry": [
+			{"sys": {"id": "author", "type": "Entry"}, "fields": {
+				"name": "Ada",
+				"favorite": {"sys": {"type": "Link", "linkType": "Entry", "id": "post"}},
+				"friend": {"sys": {"type": "Link", "linkType": "Entry", "id": "other"}}
+			}},
+			{"sys": {"id": "other", "type": "Entry"}, "fields": {
+				"author": {"sys": {"type": "Link", "linkType": "Entry", "id": "author"}}
+			}}
+		],
+		"Asset": [
+			{"sys": {"id": "photo", "type": "Asset", "locale": "en-US"}, "fields": {"title": "Photo", "file": {"url": "//images/photo.jpg"}}}
+		]
+	},
+	"errors": [
+		{"sys": {"id": "notResolvable", "type": "error"}, "details": {"type": "Link", "linkType": "Asset", "id": "gone"}}
+	]
+}`
+
+func TestResolveLinks(t *testing.T) {
+	var col Collection[Entry]
+	require.NoError(t, json.Unmarshal([]byte(resolveCollectionJSON), &col))
+
+	entries, unresolved := ResolveLinks(&col, 2)
+	require.Len(t, entries, 1)
+	post := entries[0]
+
+	author, ok := post.Fields["author"].(*Entry)
+	require.True(t, ok)
+	assert.Equal(t, "Ada", author.Fields["name"])
+	// cycle back to the post is kept as link
+	assert.Equal(t, map[string]interface{}{
+		"sys": map[string]interface{}{"type": "Link", "linkType": "Entry", "id": "post"},
+	}, author.Fields["favorite"])
+	// depth is exhausted
+	friend, ok := author.Fields["friend"].(*Entry)
+	require.True(t, ok)
+	_, ok = friend.Fields["author"].(map[string]interface{})
+	assert.True(t, ok)
+
+	related := post.Fields["related"].([]interface{})
+	other, ok := related[0].(*Entry)
+	require.True(t, ok)
+	assert.Equal(t, "other", other.Sys.ID)
+	_, ok = related[1].(map[string]interface{})
+	assert.True(t, ok)
+
+	body := post.Fields["body"].(map[string]interface{})
+	node := body["content"].([]interface{})[0].(map[string]interface{})
+	photo, ok := node["data"].(map[string]interface{})["target"].(*Asset)
+	require.True(t, ok)
+	assert.Equal(t, "Photo", photo.Fields.Title["en-US"])
+	assert.Equal(t, "//images/photo.jpg", photo.Fields.File["en-US"].URL)
+
+	assert.Equal(t, []*Link{NewLink("Asset", "gone"), NewLink("Entry", "missing")}, unresolved)
+
+	// the collection is not modified
+	_, ok = col.Items[0].Fields["author"].(map[string]interface{})
+	assert.True(t, ok)
+}
+
+func TestResolveLinksWithoutSys(t *testing.T) {
+	var col Collection[Entry]
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"items": [{"fields": {"author": {"sys": {"type": "Link", "linkType": "Entry", "id": "author"}}}}],
+		"includes": {
+			"Entry": [{"fields": {"name": "Nobody"}}, {"sys": {"id": "author", "type": "Entry"}, "fields": {"name": "Ada"}}],
+			"Asset": [{"fields": {"title": {"en-US": "Photo"}}}]
+		}
+	}`), &col))
+
+	entries, unresolved := ResolveLinks(&col, 1)
+	require.Len(t, entries, 1)
+	author, ok := entries[0].Fields["author"].(*Entry)
+	require.True(t, ok)
+	assert.Equal(t, "Ada", author.Fields["name"])
+	assert.Empty(t, unresolved)
+}
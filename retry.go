@@ -0,0 +1,182 @@
+package contentful
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one.
+	// A value <= 1 disables retries.
+	MaxAttempts int
+	// MinBackoff is the base delay used for the first retry
+	MinBackoff time.Duration
+	// MaxBackoff caps the computed and the server requested delay
+	MaxBackoff time.Duration
+	// Jitter is the random factor (0-1) applied to the computed delay
+	Jitter float64
+	// Retryable decides whether the attempt should be retried. res is nil
+	// when the request failed without a response.
+	Retryable func(req *http.Request, res *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy returns the policy used by new clients
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 5,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+		Retryable:   DefaultRetryable,
+	}
+}
+
+// NoRetryPolicy returns a policy which never retries
+func NoRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 1}
+}
+
+// DefaultRetryable retries rate limited requests and, for idempotent methods,
+// server errors and network failures.
+func DefaultRetryable(req *http.Request, res *http.Response, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if res != nil && res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	// quota errors such as too many spaces are only retried when the api
+	// tells us when the limit resets
+	var rateLimitExceededError RateLimitExceededError
+	if errors.As(err, &rateLimitExceededError) {
+		_, ok := serverBackoff(res)
+		return ok
+	}
+
+	if !isIdempotent(req.Method) {
+		return false
+	}
+
+	if res == nil {
+		return err != nil
+	}
+
+	switch res.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetRetryPolicy sets the policy used to retry failed requests
+func (c *Contentful) SetRetryPolicy(policy *RetryPolicy) *Contentful {
+	c.retryPolicy = policy
+	return c
+}
+
+// Backoff returns the delay before the given retry attempt (starting at 1).
+// Server provided X-Contentful-Ratelimit-Reset and Retry-After headers take
+// precedence over the computed delay.
+func (p *RetryPolicy) Backoff(attempt int, res *http.Response) time.Duration {
+	if wait, ok := serverBackoff(res); ok {
+		if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+			return p.MaxBackoff
+		}
+		return wait
+	}
+
+	wait := float64(p.MinBackoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && wait > float64(p.MaxBackoff) {
+		wait = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		wait += wait * p.Jitter * (2*rand.Float64() - 1) //nolint:gosec
+	}
+
+	return time.Duration(wait)
+}
+
+func (p *RetryPolicy) retryable(req *http.Request, res *http.Response, err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(req, res, err)
+	}
+	return DefaultRetryable(req, res, err)
+}
+
+func serverBackoff(res *http.Response) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+
+	if reset := res.Header.Get("X-Contentful-Ratelimit-Reset"); reset != "" {
+		if seconds, err := strconv.Atoi(reset); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	if retryAfter := res.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return max(time.Until(date), 0), true
+		}
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// rewind prepares req to be sent again
+func rewind(req *http.Request) (*http.Request, bool) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = body
+	return clone, true
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,158 @@
+package contentful
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+	policy.MaxBackoff = 10 * time.Millisecond
+	return policy
+}
+
+func TestRetryServerError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"sys":{"type":"Error","id":"ServerError"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(readTestData(t, "space-1.json")))
+	}))
+	defer server.Close()
+
+	cma := NewCMA(CMAToken).SetRetryPolicy(testRetryPolicy())
+	cma.BaseURL = server.URL
+
+	space, err := cma.Spaces.Get(t.Context(), "id1")
+	require.NoError(t, err)
+	assert.Equal(t, "id1", space.Sys.ID)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte(`{"sys":{"type":"Error","id":"BadGateway"}}`))
+	}))
+	defer server.Close()
+
+	policy := testRetryPolicy()
+	policy.MaxAttempts = 2
+	cma := NewCMA(CMAToken).SetRetryPolicy(policy)
+	cma.BaseURL = server.URL
+
+	_, err := cma.Spaces.Get(t.Context(), "id1")
+	require.Error(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRetryNonIdempotentServerError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"sys":{"type":"Error","id":"ServerError"}}`))
+	}))
+	defer server.Close()
+
+	cma := NewCMA(CMAToken).SetRetryPolicy(testRetryPolicy())
+	cma.BaseURL = server.URL
+
+	err := cma.Spaces.Upsert(t.Context(), &Space{Name: "new space"})
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryResendsBody(t *testing.T) {
+	var calls atomic.Int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if calls.Add(1) == 1 {
+			w.Header().Set("X-Contentful-Ratelimit-Reset", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(readTestData(t, "error-ratelimit.json")))
+			return
+		}
+		_, _ = w.Write([]byte(readTestData(t, "spaces-newspace.json")))
+	}))
+	defer server.Close()
+
+	cma := NewCMA(CMAToken).SetRetryPolicy(testRetryPolicy())
+	cma.BaseURL = server.URL
+
+	err := cma.Spaces.Upsert(t.Context(), &Space{Name: "new space"})
+	require.NoError(t, err)
+	require.Len(t, bodies, 2)
+	assert.JSONEq(t, `{"name":"new space"}`, bodies[0])
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestRetryContextCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Contentful-Ratelimit-Reset", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(readTestData(t, "error-ratelimit.json")))
+	}))
+	defer server.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.MaxBackoff = time.Minute
+	cma := NewCMA(CMAToken).SetRetryPolicy(policy)
+	cma.BaseURL = server.URL
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := cma.Spaces.Get(ctx, "id1")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		MinBackoff: 100 * time.Millisecond,
+		MaxBackoff: time.Second,
+	}
+
+	t.Run("exponential", func(t *testing.T) {
+		assert.Equal(t, 100*time.Millisecond, policy.Backoff(1, nil))
+		assert.Equal(t, 200*time.Millisecond, policy.Backoff(2, nil))
+		assert.Equal(t, 400*time.Millisecond, policy.Backoff(3, nil))
+		assert.Equal(t, time.Second, policy.Backoff(10, nil))
+	})
+	t.Run("ratelimit reset", func(t *testing.T) {
+		res := &http.Response{Header: http.Header{}}
+		res.Header.Set("X-Contentful-Ratelimit-Reset", "0")
+		assert.Equal(t, time.Duration(0), policy.Backoff(3, res))
+	})
+	t.Run("retry after capped", func(t *testing.T) {
+		res := &http.Response{Header: http.Header{}}
+		res.Header.Set("Retry-After", "120")
+		assert.Equal(t, time.Second, policy.Backoff(1, res))
+	})
+	t.Run("jitter", func(t *testing.T) {
+		jittered := &RetryPolicy{MinBackoff: 100 * time.Millisecond, Jitter: 0.5}
+		for range 20 {
+			wait := jittered.Backoff(1, nil)
+			assert.GreaterOrEqual(t, wait, 50*time.Millisecond)
+			assert.LessOrEqual(t, wait, 150*time.Millisecond)
+		}
+	})
+}
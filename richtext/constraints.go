@@ -0,0 +1,76 @@
+package richtext
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Constraints restrict the node and mark types of a document like the
+// enabledNodeTypes and enabledMarks validations of a rich text field
+type Constraints struct {
+	// NodeTypes are the enabled node types, all node types are enabled if nil
+	NodeTypes []NodeType
+	// Marks are the enabled marks, all marks are enabled if nil
+	Marks []MarkType
+}
+
+// ConstraintError lists the node and mark types of a document which are not
+// enabled
+type ConstraintError struct {
+	NodeTypes []NodeType
+	Marks     []MarkType
+}
+
+// structural node types can not be disabled
+var structural = []NodeType{
+	NodeTypeDocument,
+	NodeTypeParagraph,
+	NodeTypeText,
+	NodeTypeListItem,
+	NodeTypeTableRow,
+	NodeTypeTableCell,
+	NodeTypeTableHeaderCell,
+}
+
+// Validate returns a *ConstraintError if the document contains node or
+// mark types which are not enabled. A nil Constraints enables everything.
+func (c *Constraints) Validate(doc *Node) error {
+	if c == nil {
+		return nil
+	}
+
+	var err ConstraintError
+	doc.Walk(func(node *Node) bool {
+		if c.NodeTypes != nil &&
+			!slices.Contains(structural, node.NodeType) &&
+			!slices.Contains(c.NodeTypes, node.NodeType) &&
+			!slices.Contains(err.NodeTypes, node.NodeType) {
+			err.NodeTypes = append(err.NodeTypes, node.NodeType)
+		}
+		for _, mark := range node.Marks {
+			if c.Marks != nil && !slices.Contains(c.Marks, mark.Type) && !slices.Contains(err.Marks, mark.Type) {
+				err.Marks = append(err.Marks, mark.Type)
+			}
+		}
+		return true
+	})
+
+	if len(err.NodeTypes) == 0 && len(err.Marks) == 0 {
+		return nil
+	}
+
+	return &err
+}
+
+func (e *ConstraintError) Error() string {
+	var problems []string
+	if len(e.NodeTypes) > 0 {
+		problems = append(problems, fmt.Sprintf("node types %q are not enabled", e.NodeTypes))
+	}
+	if len(e.Marks) > 0 {
+		problems = append(problems, fmt.Sprintf("marks %q are not enabled", e.Marks))
+	}
+
+	return "rich text: " + strings.Join(problems, ", ")
+}
@@ -0,0 +1,83 @@
+package richtext
+
+import (
+	"slices"
+	"strings"
+)
+
+// withMark returns a copy of marks including the mark
+func withMark(marks []MarkType, mark MarkType) []MarkType {
+	if slices.Contains(marks, mark) {
+		return marks
+	}
+
+	return append(slices.Clip(marks), mark)
+}
+
+// sameMarks reports whether the text nodes have the same marks
+func sameMarks(a, b *Node) bool {
+	if len(a.Marks) != len(b.Marks) {
+		return false
+	}
+	for _, mark := range a.Marks {
+		if !b.HasMark(mark.Type) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// inlineContent merges adjacent text nodes with the same marks, drops empty
+// text nodes and trims the content. Paragraphs, headings and hyperlinks
+// require at least one text node, so an empty text is returned for empty
+// content.
+func inlineContent(nodes []*Node) []*Node {
+	var content []*Node
+	for _, node := range nodes {
+		if node.NodeType == NodeTypeHyperlink {
+			node.Content = inlineContent(node.Content)
+		}
+		if node.NodeType != NodeTypeText {
+			content = append(content, node)
+			continue
+		}
+		if node.Value == "" {
+			continue
+		}
+		if len(content) > 0 {
+			if last := content[len(content)-1]; last.NodeType == NodeTypeText && sameMarks(last, node) {
+				last.Value += node.Value
+				continue
+			}
+		}
+		content = append(content, node)
+	}
+
+	if len(content) > 0 {
+		if first := content[0]; first.NodeType == NodeTypeText && !first.HasMark(MarkCode) {
+			first.Value = strings.TrimLeft(first.Value, " \t\n")
+		}
+		if last := content[len(content)-1]; last.NodeType == NodeTypeText && !last.HasMark(MarkCode) {
+			last.Value = strings.TrimRight(last.Value, " \t\n")
+		}
+		content = slices.DeleteFunc(content, func(node *Node) bool {
+			return node.NodeType == NodeTypeText && node.Value == ""
+		})
+	}
+
+	if len(content) == 0 {
+		return []*Node{NewText("")}
+	}
+
+	return content
+}
+
+// blockContent ensures list items and table cells contain a paragraph
+func blockContent(nodes []*Node) []*Node {
+	if len(nodes) == 0 {
+		return []*Node{NewNode(NodeTypeParagraph, NewText(""))}
+	}
+
+	return nodes
+}
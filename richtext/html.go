@@ -0,0 +1,351 @@
+package richtext
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// htmlNode is an element or, without name, a text of a parsed HTML fragment
+type htmlNode struct {
+	name     string
+	attrs    map[string]string
+	text     string
+	children []*htmlNode
+}
+
+var htmlBlockTypes = map[string]NodeType{
+	"p":          NodeTypeParagraph,
+	"h1":         NodeTypeHeading1,
+	"h2":         NodeTypeHeading2,
+	"h3":         NodeTypeHeading3,
+	"h4":         NodeTypeHeading4,
+	"h5":         NodeTypeHeading5,
+	"h6":         NodeTypeHeading6,
+	"ul":         NodeTypeUnorderedList,
+	"ol":         NodeTypeOrderedList,
+	"blockquote": NodeTypeQuote,
+	"hr":         NodeTypeHR,
+	"table":      NodeTypeTable,
+	"pre":        NodeTypeParagraph,
+}
+
+// htmlContainers are block elements whose content is converted in place
+var htmlContainers = map[string]bool{
+	"html": true, "body": true, "div": true, "section": true, "article": true,
+	"main": true, "header": true, "footer": true, "aside": true, "nav": true,
+	"figure": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"thead": true, "tbody": true, "tfoot": true, "tr": true, "td": true, "th": true,
+}
+
+// htmlSkipped are elements dropped with their content
+var htmlSkipped = map[string]bool{
+	"head": true, "script": true, "style": true, "template": true, "noscript": true,
+}
+
+var htmlWhitespace = regexp.MustCompile(`[ \t\r\n\f]+`)
+
+// FromHTML converts a HTML fragment into a document and validates it against
+// the constraints, which can be nil.
+//
+// Supported are paragraphs, headings, lists, block quotes, horizontal rules,
+// tables, links, line breaks and the b, strong, i, em, u, code, sup, sub, s
+// and del elements as marks. Preformatted text becomes a paragraph of code
+// marked text and images become hyperlinks. Other elements are replaced by
+// their content, script and style elements are dropped. Missing end tags are
+// tolerated.
+func FromHTML(src string, constraints *Constraints) (*Node, error) {
+	root, err := parseHTML(src)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := NewDocument(htmlBlocks(root.children)...)
+	if err := constraints.Validate(doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func parseHTML(src string) (*htmlNode, error) {
+	// the end tag of the wrapping element closes all open elements
+	d := xml.NewDecoder(strings.NewReader("<fragment>" + src + "</fragment>"))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	root := &htmlNode{}
+	stack := []*htmlNode{root}
+	for {
+		token, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			return root.children[0], nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parent := stack[len(stack)-1]
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &htmlNode{name: strings.ToLower(t.Name.Local), attrs: map[string]string{}}
+			for _, attr := range t.Attr {
+				node.attrs[strings.ToLower(attr.Name.Local)] = attr.Value
+			}
+			stack = closeImplicitly(stack, node.name)
+			parent = stack[len(stack)-1]
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			// end tags of elements closed implicitly are ignored
+			name := strings.ToLower(t.Name.Local)
+			for i := len(stack) - 1; i > 0; i-- {
+				if stack[i].name == name {
+					stack = stack[:i]
+					break
+				}
+			}
+		case xml.CharData:
+			parent.children = append(parent.children, &htmlNode{text: string(t)})
+		}
+	}
+}
+
+// closeImplicitly closes the open elements ended by the start tag like
+// browsers do, e.g. an open paragraph by the start of a list
+func closeImplicitly(stack []*htmlNode, name string) []*htmlNode {
+	var closed, bounds []string
+	switch name {
+	case "li":
+		closed, bounds = []string{"li"}, []string{"ul", "ol"}
+	case "tr":
+		closed, bounds = []string{"tr"}, []string{"table", "thead", "tbody", "tfoot"}
+	case "td", "th":
+		closed, bounds = []string{"td", "th"}, []string{"tr", "table"}
+	case "thead", "tbody", "tfoot":
+		closed, bounds = []string{"thead", "tbody", "tfoot"}, []string{"table"}
+	default:
+		if _, ok := htmlBlockTypes[name]; !ok && !htmlContainers[name] {
+			return stack
+		}
+		closed, bounds = []string{"p"}, []string{"li", "td", "th", "blockquote", "div"}
+	}
+
+	for i := len(stack) - 1; i > 0; i-- {
+		if slices.Contains(closed, stack[i].name) {
+			return stack[:i]
+		}
+		if slices.Contains(bounds, stack[i].name) {
+			break
+		}
+	}
+
+	return stack
+}
+
+// htmlBlocks converts the nodes into blocks, wrapping inline content into
+// paragraphs
+func htmlBlocks(nodes []*htmlNode) []*Node {
+	var blocks []*Node
+	var inline []*htmlNode
+	flush := func() {
+		if content := htmlInlineContent(inline); content != nil {
+			blocks = append(blocks, NewNode(NodeTypeParagraph, content...))
+		}
+		inline = nil
+	}
+
+	for _, node := range nodes {
+		_, block := htmlBlockTypes[node.name]
+		switch {
+		case htmlSkipped[node.name]:
+		case block:
+			flush()
+			blocks = append(blocks, htmlBlock(node)...)
+		case htmlContainers[node.name]:
+			flush()
+			blocks = append(blocks, htmlBlocks(node.children)...)
+		default:
+			inline = append(inline, node)
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+func htmlBlock(node *htmlNode) []*Node {
+	nodeType := htmlBlockTypes[node.name]
+	switch node.name {
+	case "hr":
+		return []*Node{NewNode(NodeTypeHR)}
+	case "pre":
+		code := strings.TrimPrefix(strings.TrimSuffix(htmlText(node), "\n"), "\n")
+		return []*Node{NewNode(NodeTypeParagraph, inlineContent([]*Node{NewText(code, MarkCode)})...)}
+	case "ul", "ol":
+		list := NewNode(nodeType)
+		for _, child := range node.children {
+			switch {
+			case child.name == "li":
+				list.Content = append(list.Content, NewNode(NodeTypeListItem, blockContent(htmlBlocks(child.children))...))
+			case (child.name == "ul" || child.name == "ol") && len(list.Content) > 0:
+				// lists nested without an item belong to the previous item
+				item := list.Content[len(list.Content)-1]
+				item.Content = append(item.Content, htmlBlock(child)...)
+			}
+		}
+		if len(list.Content) == 0 {
+			return nil
+		}
+		return []*Node{list}
+	case "blockquote":
+		return []*Node{NewNode(NodeTypeQuote, blockContent(htmlBlocks(node.children))...)}
+	case "table":
+		table := NewNode(NodeTypeTable)
+		for _, row := range htmlRows(node) {
+			tableRow := NewNode(NodeTypeTableRow)
+			for _, cell := range row.children {
+				cellType := NodeTypeTableCell
+				switch cell.name {
+				case "th":
+					cellType = NodeTypeTableHeaderCell
+				case "td":
+				default:
+					continue
+				}
+				tableRow.Content = append(tableRow.Content, NewNode(cellType, blockContent(htmlBlocks(cell.children))...))
+			}
+			if len(tableRow.Content) > 0 {
+				table.Content = append(table.Content, tableRow)
+			}
+		}
+		if len(table.Content) == 0 {
+			return nil
+		}
+		return []*Node{table}
+	default:
+		// paragraphs and headings
+		content := htmlInlineContent(node.children)
+		if content == nil {
+			return nil
+		}
+		return []*Node{NewNode(nodeType, content...)}
+	}
+}
+
+// htmlRows returns the rows of a table including those of its sections
+func htmlRows(node *htmlNode) []*htmlNode {
+	var rows []*htmlNode
+	for _, child := range node.children {
+		switch child.name {
+		case "tr":
+			rows = append(rows, child)
+		case "thead", "tbody", "tfoot":
+			rows = append(rows, htmlRows(child)...)
+		}
+	}
+
+	return rows
+}
+
+// htmlInlineContent converts the nodes into inline content, nil if there is
+// nothing but whitespace
+func htmlInlineContent(nodes []*htmlNode) []*Node {
+	inline := htmlInline(nodes, nil)
+	collapseWhitespace(inline)
+
+	content := inlineContent(inline)
+	if len(content) == 1 && content[0].NodeType == NodeTypeText && content[0].Value == "" {
+		return nil
+	}
+
+	return content
+}
+
+func htmlInline(nodes []*htmlNode, marks []MarkType) []*Node {
+	var inline []*Node
+	for _, node := range nodes {
+		switch {
+		case node.name == "":
+			inline = append(inline, NewText(htmlWhitespace.ReplaceAllString(node.text, " "), marks...))
+		case node.name == "br":
+			inline = append(inline, NewText("\n", marks...))
+		case node.name == "a":
+			content := htmlInline(node.children, marks)
+			if href := node.attrs["href"]; href != "" {
+				inline = append(inline, NewHyperlink(href, content...))
+			} else {
+				inline = append(inline, content...)
+			}
+		case node.name == "img":
+			if src := node.attrs["src"]; src != "" {
+				alt := node.attrs["alt"]
+				if alt == "" {
+					alt = src
+				}
+				inline = append(inline, NewHyperlink(src, NewText(alt, marks...)))
+			}
+		case htmlSkipped[node.name]:
+		default:
+			if mark, ok := htmlMarkTags[node.name]; ok {
+				inline = append(inline, htmlInline(node.children, withMark(marks, mark))...)
+			} else {
+				inline = append(inline, htmlInline(node.children, marks)...)
+			}
+		}
+	}
+
+	return inline
+}
+
+// collapseWhitespace removes spaces following a space or a line break and
+// spaces preceding a line break, like browsers render them
+func collapseWhitespace(nodes []*Node) {
+	var texts []*Node
+	for _, node := range nodes {
+		node.Walk(func(node *Node) bool {
+			if node.NodeType == NodeTypeText {
+				texts = append(texts, node)
+			}
+			return true
+		})
+	}
+
+	space := true
+	for i, text := range texts {
+		if text.Value == "\n" {
+			if i > 0 {
+				texts[i-1].Value = strings.TrimRight(texts[i-1].Value, " ")
+			}
+			space = true
+			continue
+		}
+		if space {
+			text.Value = strings.TrimLeft(text.Value, " ")
+		}
+		if text.Value != "" {
+			space = strings.HasSuffix(text.Value, " ")
+		}
+	}
+}
+
+// htmlText returns the text content of the node
+func htmlText(node *htmlNode) string {
+	if node.name == "" {
+		return node.text
+	}
+	if node.name == "br" {
+		return "\n"
+	}
+
+	var b strings.Builder
+	for _, child := range node.children {
+		b.WriteString(htmlText(child))
+	}
+
+	return b.String()
+}
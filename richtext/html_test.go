@@ -0,0 +1,104 @@
+package richtext
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromHTML(t *testing.T) {
+	doc, err := FromHTML(`<h1>Cats</h1>
+<p>Nyan <strong>cat</strong> &amp;
+  <a href="https://example.com">friends</a></p>`, nil)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"nodeType": "document", "data": {}, "content": [
+			{"nodeType": "heading-1", "data": {}, "content": [
+				{"nodeType": "text", "value": "Cats", "marks": [], "data": {}}
+			]},
+			{"nodeType": "paragraph", "data": {}, "content": [
+				{"nodeType": "text", "value": "Nyan ", "marks": [], "data": {}},
+				{"nodeType": "text", "value": "cat", "marks": [{"type": "bold"}], "data": {}},
+				{"nodeType": "text", "value": " & ", "marks": [], "data": {}},
+				{"nodeType": "hyperlink", "data": {"uri": "https://example.com"}, "content": [
+					{"nodeType": "text", "value": "friends", "marks": [], "data": {}}
+				]}
+			]}
+		]
+	}`, string(data))
+}
+
+func TestFromHTMLBlocks(t *testing.T) {
+	doc, err := FromHTML(`<!DOCTYPE html>
+<html><head><title>ignored</title><style>p {}</style></head><body>
+<div>loose <em>text</em><br>
+next line</div>
+<ul>
+  <li>one
+    <ol><li><p>nested</p></li></ol>
+  <li>two
+</ul>
+<blockquote><p>meow</blockquote>
+<hr>
+<table>
+  <thead><tr><th>Name<th>Lives</tr></thead>
+  <tbody><tr><td>Nyan<td>9</tr></tbody>
+</table>
+<pre>
+func main() {
+  fmt.Println("&lt;3")
+}
+</pre>
+<p>unclosed <b>bold
+<p><img src="photo.jpg" alt="Photo"> <span>span</span> <script>alert(1)</script></p>
+</body></html>`, nil)
+	require.NoError(t, err)
+
+	markdown, err := (&MarkdownRenderer{}).Render(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "loose _text_\\\nnext line\n\n"+
+		"- one\n\n  1. nested\n- two\n\n"+
+		"> meow\n\n"+
+		"---\n\n"+
+		"| Name | Lives |\n| --- | --- |\n| Nyan | 9 |\n\n"+
+		"`func main() {\n  fmt.Println(\"<3\")\n}`\n\n"+
+		"unclosed **bold**\n\n"+
+		"[Photo](photo.jpg) span\n", markdown)
+}
+
+func TestFromHTMLMarks(t *testing.T) {
+	doc, err := FromHTML(`<p><u>u</u><sup>2</sup><sub>x</sub><del>gone</del> <code>code</code><i><b>both</b></i></p>`, nil)
+	require.NoError(t, err)
+
+	html, err := (&HTMLRenderer{}).Render(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "<p><u>u</u><sup>2</sup><sub>x</sub><s>gone</s> <code>code</code><i><b>both</b></i></p>", html)
+}
+
+func TestFromHTMLRoundTrip(t *testing.T) {
+	doc, err := Parse([]byte(documentJSON))
+	require.NoError(t, err)
+
+	html, err := (&HTMLRenderer{}).Render(doc)
+	require.NoError(t, err)
+
+	converted, err := FromHTML(html, nil)
+	require.NoError(t, err)
+
+	rendered, err := (&HTMLRenderer{}).Render(converted)
+	require.NoError(t, err)
+	assert.Equal(t, html, rendered)
+}
+
+func TestFromHTMLConstraints(t *testing.T) {
+	_, err := FromHTML("<h2>Title</h2><p><u>u</u></p>", &Constraints{NodeTypes: []NodeType{}, Marks: []MarkType{MarkBold}})
+	var constraintErr *ConstraintError
+	require.ErrorAs(t, err, &constraintErr)
+	assert.Equal(t, []NodeType{NodeTypeHeading2}, constraintErr.NodeTypes)
+	assert.Equal(t, []MarkType{MarkUnderline}, constraintErr.Marks)
+}
@@ -0,0 +1,514 @@
+package richtext
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	mdHeading  = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	mdSetext   = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	mdListItem = regexp.MustCompile(`^( {0,3})([-+*]|\d{1,9}[.)])( +|$)`)
+	mdQuote    = regexp.MustCompile(`^ {0,3}> ?`)
+	mdFence    = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	mdTableSep = regexp.MustCompile(`^ {0,3}\|?[ \t]*:?-+:?[ \t]*(\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	mdAutolink = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^\s<>]*)>`)
+	mdHTMLMark = regexp.MustCompile(`^<(u|sup|sub|b|strong|i|em|code|s|del)>`)
+)
+
+// htmlMarkTags maps inline HTML elements to marks
+var htmlMarkTags = map[string]MarkType{
+	"b":      MarkBold,
+	"strong": MarkBold,
+	"i":      MarkItalic,
+	"em":     MarkItalic,
+	"u":      MarkUnderline,
+	"code":   MarkCode,
+	"sup":    MarkSuperscript,
+	"sub":    MarkSubscript,
+	"s":      MarkStrikethrough,
+	"del":    MarkStrikethrough,
+	"strike": MarkStrikethrough,
+}
+
+// FromMarkdown converts Markdown into a document and validates it against
+// the constraints, which can be nil.
+//
+// Supported are ATX and setext headings, paragraphs, block quotes, ordered
+// and unordered lists, thematic breaks, GFM tables, emphasis, strong
+// emphasis, strikethrough, code spans, links, autolinks and the inline HTML
+// elements written by the MarkdownRenderer for underline, superscript and
+// subscript. Fenced code blocks become paragraphs of code marked text and
+// images become hyperlinks, rich text has no equivalent for them.
+func FromMarkdown(markdown string, constraints *Constraints) (*Node, error) {
+	markdown = strings.ReplaceAll(markdown, "\r\n", "\n")
+	markdown = strings.ReplaceAll(markdown, "\t", "    ")
+
+	doc := NewDocument(parseMarkdownBlocks(strings.Split(markdown, "\n"))...)
+	if err := constraints.Validate(doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func parseMarkdownBlocks(lines []string) []*Node {
+	var nodes []*Node
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+		case mdFence.MatchString(line):
+			fence := mdFence.FindStringSubmatch(line)[1]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			i++
+			nodes = append(nodes, NewNode(NodeTypeParagraph, inlineContent([]*Node{NewText(strings.Join(code, "\n"), MarkCode)})...))
+		case mdHeading.MatchString(line):
+			m := mdHeading.FindStringSubmatch(line)
+			nodes = append(nodes, NewNode(Headings[len(m[1])-1], markdownInlineContent(m[2])...))
+			i++
+		case isThematicBreak(line):
+			nodes = append(nodes, NewNode(NodeTypeHR))
+			i++
+		case mdQuote.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && mdQuote.MatchString(lines[i]); i++ {
+				quoted = append(quoted, mdQuote.ReplaceAllString(lines[i], ""))
+			}
+			nodes = append(nodes, NewNode(NodeTypeQuote, blockContent(parseMarkdownBlocks(quoted))...))
+		case mdListItem.MatchString(line):
+			var list *Node
+			list, i = parseMarkdownList(lines, i)
+			nodes = append(nodes, list)
+		case i+1 < len(lines) && strings.Contains(line, "|") && strings.Contains(lines[i+1], "|") && mdTableSep.MatchString(lines[i+1]):
+			var table *Node
+			table, i = parseMarkdownTable(lines, i)
+			nodes = append(nodes, table)
+		default:
+			nodeType := NodeTypeParagraph
+			paragraph := []string{strings.TrimLeft(line, " ")}
+			for i++; i < len(lines) && !isBlank(lines[i]); i++ {
+				if m := mdSetext.FindStringSubmatch(lines[i]); m != nil {
+					nodeType = NodeTypeHeading2
+					if m[1][0] == '=' {
+						nodeType = NodeTypeHeading1
+					}
+					i++
+					break
+				}
+				if startsMarkdownBlock(lines[i]) {
+					break
+				}
+				paragraph = append(paragraph, strings.TrimLeft(lines[i], " "))
+			}
+			nodes = append(nodes, NewNode(nodeType, markdownInlineContent(strings.Join(paragraph, "\n"))...))
+		}
+	}
+
+	return nodes
+}
+
+// parseMarkdownList parses the list starting at line i and returns it with the
+// index of the line following it
+func parseMarkdownList(lines []string, i int) (*Node, int) {
+	marker := mdListItem.FindStringSubmatch(lines[i])[2]
+	ordered := isOrderedMarker(marker)
+	list := NewNode(NodeTypeUnorderedList)
+	if ordered {
+		list.NodeType = NodeTypeOrderedList
+	}
+
+	for i < len(lines) {
+		m := mdListItem.FindStringSubmatch(lines[i])
+		// a different bullet or delimiter starts a new list
+		if m == nil || m[2][len(m[2])-1] != marker[len(marker)-1] || isThematicBreak(lines[i]) {
+			break
+		}
+
+		indent := len(m[0])
+		if len(m[3]) == 0 || len(m[3]) > 4 {
+			indent = len(m[1]) + len(m[2]) + 1
+		}
+		item := []string{strings.TrimLeft(lines[i][len(m[0]):], " ")}
+
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if isBlank(line) {
+				// blank lines continue the item if the next line is indented
+				j := i
+				for j < len(lines) && isBlank(lines[j]) {
+					j++
+				}
+				if j < len(lines) && indentation(lines[j]) >= indent {
+					item = append(item, "")
+					continue
+				}
+				break
+			}
+			if indentation(line) >= indent {
+				item = append(item, line[indent:])
+				continue
+			}
+			// lazy continuation of a paragraph
+			if item[len(item)-1] != "" && !startsMarkdownBlock(line) {
+				item = append(item, strings.TrimLeft(line, " "))
+				continue
+			}
+			break
+		}
+		list.Content = append(list.Content, NewNode(NodeTypeListItem, blockContent(parseMarkdownBlocks(item))...))
+
+		// blank lines between items
+		j := i
+		for j < len(lines) && isBlank(lines[j]) {
+			j++
+		}
+		if j == i || (j < len(lines) && mdListItem.MatchString(lines[j])) {
+			i = j
+			continue
+		}
+		break
+	}
+
+	return list, i
+}
+
+// parseMarkdownTable parses the table starting at line i and returns it with the
+// index of the line following it
+func parseMarkdownTable(lines []string, i int) (*Node, int) {
+	header := parseMarkdownTableCells(lines[i])
+	table := NewNode(NodeTypeTable, parseMarkdownTableRow(header, NodeTypeTableHeaderCell, len(header)))
+	for i += 2; i < len(lines) && !isBlank(lines[i]) && strings.Contains(lines[i], "|"); i++ {
+		table.Content = append(table.Content, parseMarkdownTableRow(parseMarkdownTableCells(lines[i]), NodeTypeTableCell, len(header)))
+	}
+
+	return table, i
+}
+
+func parseMarkdownTableRow(cells []string, cellType NodeType, columns int) *Node {
+	row := NewNode(NodeTypeTableRow)
+	for i := range columns {
+		var cell string
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		row.Content = append(row.Content, NewNode(cellType, NewNode(NodeTypeParagraph, markdownInlineContent(cell)...)))
+	}
+
+	return row
+}
+
+// parseMarkdownTableCells splits a table row at unescaped pipes
+func parseMarkdownTableCells(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// startsMarkdownBlock reports whether the line interrupts a paragraph
+func startsMarkdownBlock(line string) bool {
+	if m := mdListItem.FindStringSubmatch(line); m != nil && strings.TrimSpace(line[len(m[0]):]) != "" {
+		return true
+	}
+
+	return mdHeading.MatchString(line) ||
+		mdFence.MatchString(line) ||
+		mdQuote.MatchString(line) ||
+		isThematicBreak(line)
+}
+
+func isThematicBreak(line string) bool {
+	if indentation(line) > 3 {
+		return false
+	}
+	s := strings.NewReplacer(" ", "", "\t", "").Replace(line)
+	if len(s) < 3 || !strings.ContainsAny(s[:1], "-*_") {
+		return false
+	}
+
+	return strings.Count(s, s[:1]) == len(s)
+}
+
+func isOrderedMarker(marker string) bool {
+	return !strings.ContainsAny(marker, "-+*")
+}
+
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// markdownInlineContent parses the inline content of a paragraph or heading
+func markdownInlineContent(s string) []*Node {
+	return inlineContent(parseMarkdownInline(s, nil))
+}
+
+func parseMarkdownInline(s string, marks []MarkType) []*Node {
+	var nodes []*Node
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, NewText(text.String(), marks...))
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			text.WriteByte('\n')
+			i += 2
+			continue
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			text.WriteByte(s[i+1])
+			i += 2
+			continue
+		case c == '\n':
+			// two trailing spaces are a hard line break, others a soft one
+			value := text.String()
+			text.Reset()
+			if strings.HasSuffix(value, "  ") {
+				text.WriteString(strings.TrimRight(value, " ") + "\n")
+			} else {
+				text.WriteString(strings.TrimRight(value, " ") + " ")
+			}
+			i++
+			continue
+		case c == '`':
+			n := runLength(s, i)
+			if end := codeSpanEnd(s, i+n, n); end >= 0 {
+				flush()
+				code := strings.ReplaceAll(s[i+n:end], "\n", " ")
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+					code = code[1 : len(code)-1]
+				}
+				nodes = append(nodes, NewText(code, withMark(marks, MarkCode)...))
+				i = end + n
+				continue
+			}
+			text.WriteString(s[i : i+n])
+			i += n
+			continue
+		case c == '*' || c == '_' || c == '~':
+			if end, delimiter, mark, ok := emphasisEnd(s, i); ok {
+				flush()
+				nodes = append(nodes, parseMarkdownInline(s[i+len(delimiter):end], withMark(marks, mark))...)
+				i = end + len(delimiter)
+				continue
+			}
+			n := runLength(s, i)
+			text.WriteString(s[i : i+n])
+			i += n
+			continue
+		case c == '[' || (c == '!' && i+1 < len(s) && s[i+1] == '['):
+			if label, uri, end, ok := markdownLink(s, i); ok {
+				flush()
+				content := parseMarkdownInline(label, marks)
+				if c == '!' && label == "" {
+					content = []*Node{NewText(uri, marks...)}
+				}
+				nodes = append(nodes, NewHyperlink(uri, content...))
+				i = end
+				continue
+			}
+		case c == '<':
+			if m := mdAutolink.FindStringSubmatch(s[i:]); m != nil {
+				flush()
+				nodes = append(nodes, NewHyperlink(m[1], NewText(m[1], marks...)))
+				i += len(m[0])
+				continue
+			}
+			if m := mdHTMLMark.FindStringSubmatch(s[i:]); m != nil {
+				if end := strings.Index(s[i+len(m[0]):], "</"+m[1]+">"); end >= 0 {
+					flush()
+					start := i + len(m[0])
+					nodes = append(nodes, parseMarkdownInline(s[start:start+end], withMark(marks, htmlMarkTags[m[1]]))...)
+					i = start + end + len(m[1]) + 3
+					continue
+				}
+			}
+		}
+		text.WriteByte(c)
+		i++
+	}
+	flush()
+
+	return nodes
+}
+
+// emphasisEnd returns the index of the delimiter closing the emphasis
+// opened at i
+func emphasisEnd(s string, i int) (int, string, MarkType, bool) {
+	c := s[i]
+	n := runLength(s, i)
+
+	delimiter, mark := s[i:i+1], MarkItalic
+	switch {
+	case c == '~':
+		if n != 2 {
+			return 0, "", "", false
+		}
+		delimiter, mark = "~~", MarkStrikethrough
+	case n >= 2:
+		delimiter, mark = s[i:i+2], MarkBold
+	}
+
+	start := i + len(delimiter)
+	if start >= len(s) || isSpace(s[start]) || (c == '_' && i > 0 && isAlnum(s[i-1])) {
+		return 0, "", "", false
+	}
+
+	for j := start; j < len(s); {
+		switch s[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			m := runLength(s, j)
+			if end := codeSpanEnd(s, j+m, m); end >= 0 {
+				j = end + m
+				continue
+			}
+			j += m
+			continue
+		case c:
+		default:
+			j++
+			continue
+		}
+
+		m := runLength(s, j)
+		closing := m == len(delimiter) || m >= 3
+		if c == '_' && j+m < len(s) && isAlnum(s[j+m]) {
+			closing = false
+		}
+		if closing && !isSpace(s[j-1]) {
+			if end := j + m - len(delimiter); end > start {
+				return end, delimiter, mark, true
+			}
+		}
+		j += m
+	}
+
+	return 0, "", "", false
+}
+
+// markdownLink parses a [label](uri "title") link or image at i
+func markdownLink(s string, i int) (string, string, int, bool) {
+	start := i
+	if s[i] == '!' {
+		start++
+	}
+
+	depth := 0
+	j := start
+loop:
+	for ; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				break loop
+			}
+		}
+	}
+	if j+1 >= len(s) || s[j+1] != '(' {
+		return "", "", 0, false
+	}
+	label := s[start+1 : j]
+
+	depth = 0
+	k := j + 2
+	for ; k < len(s); k++ {
+		if s[k] == '(' {
+			depth++
+		} else if s[k] == ')' {
+			if depth == 0 {
+				break
+			}
+			depth--
+		}
+	}
+	if k >= len(s) {
+		return "", "", 0, false
+	}
+
+	destination := strings.TrimSpace(s[j+2 : k])
+	if fields := strings.Fields(destination); len(fields) > 0 {
+		destination = fields[0]
+	}
+	destination = strings.TrimSuffix(strings.TrimPrefix(destination, "<"), ">")
+
+	return label, destination, k + 1, true
+}
+
+// codeSpanEnd returns the index of the backtick run of length n closing a
+// code span, or -1
+func codeSpanEnd(s string, from, n int) int {
+	for j := from; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		m := runLength(s, j)
+		if m == n {
+			return j
+		}
+		j += m
+	}
+
+	return -1
+}
+
+// runLength returns the number of repetitions of the byte at i
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+
+	return n
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isAlnum(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
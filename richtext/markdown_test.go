@@ -0,0 +1,184 @@
+package richtext
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromMarkdown(t *testing.T) {
+	doc, err := FromMarkdown("# Cats\n\nNyan **cat** & [friends](https://example.com \"title\")", nil)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"nodeType": "document", "data": {}, "content": [
+			{"nodeType": "heading-1", "data": {}, "content": [
+				{"nodeType": "text", "value": "Cats", "marks": [], "data": {}}
+			]},
+			{"nodeType": "paragraph", "data": {}, "content": [
+				{"nodeType": "text", "value": "Nyan ", "marks": [], "data": {}},
+				{"nodeType": "text", "value": "cat", "marks": [{"type": "bold"}], "data": {}},
+				{"nodeType": "text", "value": " & ", "marks": [], "data": {}},
+				{"nodeType": "hyperlink", "data": {"uri": "https://example.com"}, "content": [
+					{"nodeType": "text", "value": "friends", "marks": [], "data": {}}
+				]}
+			]}
+		]
+	}`, string(data))
+}
+
+func TestFromMarkdownBlocks(t *testing.T) {
+	markdown := `Title
+=====
+
+Subtitle
+--------
+
+- one
+- two
+  continued
+
+  - nested
+
+1. first
+2) other list
+
+> quote
+> more
+
+***
+
+| Name | Lives |
+|:-----|------:|
+| Nyan | 9 \| 7 |
+| Happy |
+
+` + "```go\nfunc main() {\n}\n```\n\n![](photo.jpg)"
+
+	doc, err := FromMarkdown(markdown, nil)
+	require.NoError(t, err)
+
+	var types []NodeType
+	for _, node := range doc.Content {
+		types = append(types, node.NodeType)
+	}
+	assert.Equal(t, []NodeType{
+		NodeTypeHeading1,
+		NodeTypeHeading2,
+		NodeTypeUnorderedList,
+		NodeTypeOrderedList,
+		NodeTypeOrderedList,
+		NodeTypeQuote,
+		NodeTypeHR,
+		NodeTypeTable,
+		NodeTypeParagraph,
+		NodeTypeParagraph,
+	}, types)
+
+	list := doc.Content[2]
+	require.Len(t, list.Content, 2)
+	second := list.Content[1]
+	require.Len(t, second.Content, 2)
+	assert.Equal(t, "two continued", second.Content[0].Content[0].Value)
+	assert.Equal(t, NodeTypeUnorderedList, second.Content[1].NodeType)
+
+	assert.Equal(t, "quote more", doc.Content[5].Content[0].Content[0].Value)
+
+	table := doc.Content[7]
+	require.Len(t, table.Content, 3)
+	assert.Equal(t, NodeTypeTableHeaderCell, table.Content[0].Content[0].NodeType)
+	assert.Equal(t, "9 | 7", table.Content[1].Content[1].Content[0].Content[0].Value)
+	// missing cells are added
+	require.Len(t, table.Content[2].Content, 2)
+	assert.Equal(t, "", table.Content[2].Content[1].Content[0].Content[0].Value)
+
+	code := doc.Content[8].Content[0]
+	assert.Equal(t, "func main() {\n}", code.Value)
+	assert.True(t, code.HasMark(MarkCode))
+
+	image := doc.Content[9].Content[0]
+	assert.Equal(t, NodeTypeHyperlink, image.NodeType)
+	assert.Equal(t, "photo.jpg", image.Data.URI)
+}
+
+func TestFromMarkdownInline(t *testing.T) {
+	tests := []struct {
+		markdown string
+		texts    []string
+		marks    [][]MarkType
+	}{
+		{"*a* _b_ **c** __d__", []string{"a", " ", "b", " ", "c", " ", "d"}, [][]MarkType{{MarkItalic}, nil, {MarkItalic}, nil, {MarkBold}, nil, {MarkBold}}},
+		{"***both***", []string{"both"}, [][]MarkType{{MarkBold, MarkItalic}}},
+		{"*a **b** c*", []string{"a ", "b", " c"}, [][]MarkType{{MarkItalic}, {MarkItalic, MarkBold}, {MarkItalic}}},
+		{"~~gone~~ `*code*`", []string{"gone", " ", "*code*"}, [][]MarkType{{MarkStrikethrough}, nil, {MarkCode}}},
+		{"<u>u</u><sup>2</sup><sub>x</sub>", []string{"u", "2", "x"}, [][]MarkType{{MarkUnderline}, {MarkSuperscript}, {MarkSubscript}}},
+		{"snake_case_name 2 * 3 * 4", []string{"snake_case_name 2 * 3 * 4"}, [][]MarkType{nil}},
+		{`\*not\* [not link]`, []string{"*not* [not link]"}, [][]MarkType{nil}},
+		{"hard  \nbreak\\\nand soft\nbreak", []string{"hard\nbreak\nand soft break"}, [][]MarkType{nil}},
+		{"**unclosed", []string{"**unclosed"}, [][]MarkType{nil}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.markdown, func(t *testing.T) {
+			doc, err := FromMarkdown(test.markdown, nil)
+			require.NoError(t, err)
+			require.Len(t, doc.Content, 1)
+
+			var texts []string
+			var marks [][]MarkType
+			for _, node := range doc.Content[0].Content {
+				texts = append(texts, node.Value)
+				var nodeMarks []MarkType
+				for _, mark := range node.Marks {
+					nodeMarks = append(nodeMarks, mark.Type)
+				}
+				marks = append(marks, nodeMarks)
+			}
+			assert.Equal(t, test.texts, texts)
+			assert.Equal(t, test.marks, marks)
+		})
+	}
+}
+
+func TestFromMarkdownAutolink(t *testing.T) {
+	doc, err := FromMarkdown("see <https://example.com>", nil)
+	require.NoError(t, err)
+
+	link := doc.Content[0].Content[1]
+	assert.Equal(t, NodeTypeHyperlink, link.NodeType)
+	assert.Equal(t, "https://example.com", link.Data.URI)
+	assert.Equal(t, "https://example.com", link.Content[0].Value)
+}
+
+func TestFromMarkdownRoundTrip(t *testing.T) {
+	doc, err := Parse([]byte(documentJSON))
+	require.NoError(t, err)
+
+	markdown, err := (&MarkdownRenderer{}).Render(doc)
+	require.NoError(t, err)
+
+	converted, err := FromMarkdown(markdown, nil)
+	require.NoError(t, err)
+
+	rendered, err := (&MarkdownRenderer{}).Render(converted)
+	require.NoError(t, err)
+	assert.Equal(t, markdown, rendered)
+}
+
+func TestFromMarkdownConstraints(t *testing.T) {
+	constraints := &Constraints{NodeTypes: []NodeType{NodeTypeHyperlink}, Marks: []MarkType{}}
+
+	_, err := FromMarkdown("[link](https://example.com)", constraints)
+	require.NoError(t, err)
+
+	_, err = FromMarkdown("## Title\n\n- **bold**\n- `code`", constraints)
+	var constraintErr *ConstraintError
+	require.ErrorAs(t, err, &constraintErr)
+	assert.Equal(t, []NodeType{NodeTypeHeading2, NodeTypeUnorderedList}, constraintErr.NodeTypes)
+	assert.Equal(t, []MarkType{MarkBold, MarkCode}, constraintErr.Marks)
+	assert.EqualError(t, err, `rich text: node types ["heading-2" "unordered-list"] are not enabled, marks ["bold" "code"] are not enabled`)
+}
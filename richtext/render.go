@@ -0,0 +1,354 @@
+package richtext
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Renderer renders a document or a node
+type Renderer interface {
+	Render(node *Node) (string, error)
+}
+
+// NodeRenderer renders a node given its rendered content. It replaces the
+// default rendering of a node type, e.g. to render embedded entries.
+type NodeRenderer func(node *Node, content string) (string, error)
+
+// HTMLRenderer renders HTML. Embedded entries, assets and resources are
+// rendered empty unless a NodeRenderer is configured for them.
+type HTMLRenderer struct {
+	// Nodes replaces the default rendering of node types
+	Nodes map[NodeType]NodeRenderer
+}
+
+// MarkdownRenderer renders Markdown, using HTML tags for marks without a
+// Markdown equivalent. Embedded nodes are rendered empty unless a
+// NodeRenderer is configured for them.
+type MarkdownRenderer struct {
+	// Nodes replaces the default rendering of node types
+	Nodes map[NodeType]NodeRenderer
+}
+
+// TextRenderer renders plain text with block nodes on separate lines.
+// Embedded nodes are rendered empty unless a NodeRenderer is configured
+// for them.
+type TextRenderer struct {
+	// Nodes replaces the default rendering of node types
+	Nodes map[NodeType]NodeRenderer
+}
+
+var (
+	_ Renderer = (*HTMLRenderer)(nil)
+	_ Renderer = (*MarkdownRenderer)(nil)
+	_ Renderer = (*TextRenderer)(nil)
+)
+
+// Render renders the node as HTML
+func (r *HTMLRenderer) Render(node *Node) (string, error) {
+	return (&renderer{nodes: r.Nodes, node: renderHTML}).render(node)
+}
+
+// Render renders the node as Markdown
+func (r *MarkdownRenderer) Render(node *Node) (string, error) {
+	return (&renderer{nodes: r.Nodes, node: renderMarkdown}).render(node)
+}
+
+// Render renders the node as plain text
+func (r *TextRenderer) Render(node *Node) (string, error) {
+	return (&renderer{nodes: r.Nodes, node: renderText}).render(node)
+}
+
+// renderer walks the nodes using the configured node renderers and the
+// defaults of a format
+type renderer struct {
+	nodes map[NodeType]NodeRenderer
+	node  func(r *renderer, node *Node) (string, error)
+}
+
+func (r *renderer) render(node *Node) (string, error) {
+	if node == nil {
+		return "", nil
+	}
+
+	if fn, ok := r.nodes[node.NodeType]; ok {
+		content, err := r.content(node)
+		if err != nil {
+			return "", err
+		}
+		return fn(node, content)
+	}
+
+	return r.node(r, node)
+}
+
+// content renders the content of the node concatenated
+func (r *renderer) content(node *Node) (string, error) {
+	var b strings.Builder
+	for _, child := range node.Content {
+		s, err := r.render(child)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(s)
+	}
+
+	return b.String(), nil
+}
+
+// children renders the content of the node one by one
+func (r *renderer) children(node *Node) ([]string, error) {
+	children := make([]string, 0, len(node.Content))
+	for _, child := range node.Content {
+		s, err := r.render(child)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, s)
+	}
+
+	return children, nil
+}
+
+func isEmbedded(nodeType NodeType) bool {
+	return strings.HasPrefix(string(nodeType), "embedded-")
+}
+
+// markOrder is the order marks are applied in, innermost first, for a
+// stable output
+var markOrder = []MarkType{
+	MarkCode,
+	MarkBold,
+	MarkItalic,
+	MarkStrikethrough,
+	MarkUnderline,
+	MarkSuperscript,
+	MarkSubscript,
+}
+
+var htmlTags = map[NodeType]string{
+	NodeTypeParagraph:       "p",
+	NodeTypeHeading1:        "h1",
+	NodeTypeHeading2:        "h2",
+	NodeTypeHeading3:        "h3",
+	NodeTypeHeading4:        "h4",
+	NodeTypeHeading5:        "h5",
+	NodeTypeHeading6:        "h6",
+	NodeTypeOrderedList:     "ol",
+	NodeTypeUnorderedList:   "ul",
+	NodeTypeListItem:        "li",
+	NodeTypeQuote:           "blockquote",
+	NodeTypeTable:           "table",
+	NodeTypeTableRow:        "tr",
+	NodeTypeTableCell:       "td",
+	NodeTypeTableHeaderCell: "th",
+}
+
+var htmlMarks = map[MarkType]string{
+	MarkBold:          "b",
+	MarkItalic:        "i",
+	MarkUnderline:     "u",
+	MarkCode:          "code",
+	MarkSuperscript:   "sup",
+	MarkSubscript:     "sub",
+	MarkStrikethrough: "s",
+}
+
+func renderHTML(r *renderer, node *Node) (string, error) {
+	switch {
+	case node.NodeType == NodeTypeText:
+		s := strings.ReplaceAll(html.EscapeString(node.Value), "\n", "<br/>")
+		for _, mark := range markOrder {
+			if node.HasMark(mark) {
+				s = "<" + htmlMarks[mark] + ">" + s + "</" + htmlMarks[mark] + ">"
+			}
+		}
+		return s, nil
+	case node.NodeType == NodeTypeHR:
+		return "<hr/>", nil
+	case isEmbedded(node.NodeType):
+		return "", nil
+	}
+
+	content, err := r.content(node)
+	if err != nil {
+		return "", err
+	}
+
+	if node.NodeType == NodeTypeHyperlink {
+		return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(node.Data.URI), content), nil
+	}
+	if tag, ok := htmlTags[node.NodeType]; ok {
+		return "<" + tag + ">" + content + "</" + tag + ">", nil
+	}
+
+	// documents, entry, asset and resource hyperlinks
+	return content, nil
+}
+
+var markdownMarks = map[MarkType][2]string{
+	MarkBold:          {"**", "**"},
+	MarkItalic:        {"_", "_"},
+	MarkCode:          {"`", "`"},
+	MarkStrikethrough: {"~~", "~~"},
+	MarkUnderline:     {"<u>", "</u>"},
+	MarkSuperscript:   {"<sup>", "</sup>"},
+	MarkSubscript:     {"<sub>", "</sub>"},
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+)
+
+func renderMarkdown(r *renderer, node *Node) (string, error) {
+	switch {
+	case node.NodeType == NodeTypeText:
+		return markdownText(node), nil
+	case node.NodeType == NodeTypeHR:
+		return "---\n\n", nil
+	case isEmbedded(node.NodeType):
+		return "", nil
+	case node.NodeType == NodeTypeOrderedList || node.NodeType == NodeTypeUnorderedList:
+		return markdownList(r, node)
+	case node.NodeType == NodeTypeTable:
+		return markdownTable(r, node)
+	}
+
+	content, err := r.content(node)
+	if err != nil {
+		return "", err
+	}
+
+	switch node.NodeType {
+	case NodeTypeDocument:
+		content = strings.TrimRight(content, "\n")
+		if content == "" {
+			return "", nil
+		}
+		return content + "\n", nil
+	case NodeTypeParagraph:
+		return content + "\n\n", nil
+	case NodeTypeHyperlink:
+		return "[" + content + "](" + node.Data.URI + ")", nil
+	case NodeTypeQuote:
+		return prefixLines(strings.TrimRight(content, "\n"), "> ", ">") + "\n\n", nil
+	}
+	if level := node.HeadingLevel(); level > 0 {
+		return strings.Repeat("#", level) + " " + content + "\n\n", nil
+	}
+
+	return content, nil
+}
+
+// markdownText escapes the value and applies the marks, keeping surrounding
+// whitespace outside of the markers
+func markdownText(node *Node) string {
+	value := node.Value
+	if !node.HasMark(MarkCode) {
+		value = markdownEscaper.Replace(value)
+	}
+
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return value
+	}
+	start := strings.Index(value, trimmed)
+	s := trimmed
+	for _, mark := range markOrder {
+		if node.HasMark(mark) {
+			s = markdownMarks[mark][0] + s + markdownMarks[mark][1]
+		}
+	}
+
+	s = value[:start] + s + value[start+len(trimmed):]
+	if node.HasMark(MarkCode) {
+		return s
+	}
+
+	// line breaks within paragraphs are hard breaks
+	return strings.ReplaceAll(s, "\n", "\\\n")
+}
+
+func markdownList(r *renderer, node *Node) (string, error) {
+	items, err := r.children(node)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for i, item := range items {
+		marker := "- "
+		if node.NodeType == NodeTypeOrderedList {
+			marker = fmt.Sprintf("%d. ", i+1)
+		}
+		item = prefixLines(strings.TrimRight(item, "\n"), strings.Repeat(" ", len(marker)), "")
+		b.WriteString(marker + strings.TrimLeft(item, " ") + "\n")
+	}
+
+	return b.String() + "\n", nil
+}
+
+func markdownTable(r *renderer, node *Node) (string, error) {
+	var b strings.Builder
+	for i, row := range node.Content {
+		cells := make([]string, 0, len(row.Content))
+		for _, cell := range row.Content {
+			s, err := r.render(cell)
+			if err != nil {
+				return "", err
+			}
+			s = strings.ReplaceAll(strings.TrimSpace(s), "\n\n", "<br>")
+			cells = append(cells, strings.ReplaceAll(s, "|", `\|`))
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", len(cells)) + "\n")
+		}
+	}
+
+	return b.String() + "\n", nil
+}
+
+// prefixLines prefixes all lines of s, empty lines with the empty prefix
+func prefixLines(s, prefix, empty string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = empty
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func renderText(r *renderer, node *Node) (string, error) {
+	switch {
+	case node.NodeType == NodeTypeText:
+		return node.Value, nil
+	case isEmbedded(node.NodeType), node.NodeType == NodeTypeHR:
+		return "", nil
+	}
+
+	// block nodes are separated by new lines
+	var b strings.Builder
+	for _, child := range node.Content {
+		s, err := r.render(child)
+		if err != nil {
+			return "", err
+		}
+		if !child.IsInline() && b.Len() > 0 && s != "" {
+			b.WriteString("\n")
+		}
+		b.WriteString(s)
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,105 @@
+package richtext
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLRenderer(t *testing.T) {
+	doc, err := Parse([]byte(documentJSON))
+	require.NoError(t, err)
+
+	r := &HTMLRenderer{Nodes: map[NodeType]NodeRenderer{
+		NodeTypeEmbeddedEntryInline: func(node *Node, content string) (string, error) {
+			return `<span class="entry">` + node.Data.Target.Sys.ID + `</span>`, nil
+		},
+	}}
+	html, err := r.Render(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "<h2>Cats</h2>"+
+		`<p>Nyan <i><b>cat</b></i> &amp; <a href="https://example.com?a=1&amp;b=2">friends</a><span class="entry">happycat</span></p>`+
+		"<ul><li><p>one</p><ol><li><p>nested</p></li></ol></li><li><p><code>two</code></p></li></ul>"+
+		"<blockquote><p>meow</p></blockquote>"+
+		"<hr/>"+
+		"<table><tr><th><p>Name</p></th><th><p>Lives</p></th></tr><tr><td><p>Nyan</p></td><td><p>9</p></td></tr></table>", html)
+}
+
+func TestMarkdownRenderer(t *testing.T) {
+	doc, err := Parse([]byte(documentJSON))
+	require.NoError(t, err)
+
+	r := &MarkdownRenderer{Nodes: map[NodeType]NodeRenderer{
+		NodeTypeEmbeddedAssetBlock: func(node *Node, content string) (string, error) {
+			var asset struct {
+				Fields map[string]string `json:"fields"`
+			}
+			if err := node.Data.Target.Decode(&asset); err != nil {
+				return "", err
+			}
+			return "![" + asset.Fields["title"] + "](photo.jpg)\n\n", nil
+		},
+	}}
+	markdown, err := r.Render(doc)
+	require.NoError(t, err)
+	assert.Equal(t, `## Cats
+
+Nyan _**cat**_ & [friends](https://example.com?a=1&b=2)
+
+- one
+
+  1. nested
+- `+"`two`"+`
+
+> meow
+
+---
+
+![Photo](photo.jpg)
+
+| Name | Lives |
+| --- | --- |
+| Nyan | 9 |
+`, markdown)
+}
+
+func TestMarkdownRendererText(t *testing.T) {
+	doc := NewDocument(NewNode(NodeTypeParagraph,
+		NewText("a_b "),
+		NewText("bold ", MarkBold),
+		NewText("*code*", MarkCode),
+		NewText(" x", MarkUnderline),
+	))
+
+	markdown, err := (&MarkdownRenderer{}).Render(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "a\\_b **bold** `*code*` <u>x</u>\n", markdown)
+
+	markdown, err = (&MarkdownRenderer{}).Render(NewDocument())
+	require.NoError(t, err)
+	assert.Empty(t, markdown)
+}
+
+func TestTextRenderer(t *testing.T) {
+	doc, err := Parse([]byte(documentJSON))
+	require.NoError(t, err)
+
+	text, err := (&TextRenderer{}).Render(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "Cats\nNyan cat & friends\none\nnested\ntwo\nmeow\nName\nLives\nNyan\n9", text)
+}
+
+func TestRendererError(t *testing.T) {
+	doc, err := Parse([]byte(documentJSON))
+	require.NoError(t, err)
+
+	r := &TextRenderer{Nodes: map[NodeType]NodeRenderer{
+		NodeTypeEmbeddedAssetBlock: func(node *Node, content string) (string, error) {
+			return "", errors.New("failed")
+		},
+	}}
+	_, err = r.Render(doc)
+	assert.EqualError(t, err, "failed")
+}
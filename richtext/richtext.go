@@ -0,0 +1,311 @@
+// Package richtext provides the document model of contentful rich text
+// fields, renders documents to HTML, Markdown and plain text and converts
+// Markdown and HTML into documents.
+//
+//	doc, err := richtext.Parse(data)
+//	html, err := (&richtext.HTMLRenderer{}).Render(doc)
+//	doc, err = richtext.FromMarkdown("# Hello *world*", nil)
+package richtext
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// NodeType is the type of a rich text node
+type NodeType string
+
+// Node types
+const (
+	NodeTypeDocument               NodeType = "document"
+	NodeTypeParagraph              NodeType = "paragraph"
+	NodeTypeHeading1               NodeType = "heading-1"
+	NodeTypeHeading2               NodeType = "heading-2"
+	NodeTypeHeading3               NodeType = "heading-3"
+	NodeTypeHeading4               NodeType = "heading-4"
+	NodeTypeHeading5               NodeType = "heading-5"
+	NodeTypeHeading6               NodeType = "heading-6"
+	NodeTypeOrderedList            NodeType = "ordered-list"
+	NodeTypeUnorderedList          NodeType = "unordered-list"
+	NodeTypeListItem               NodeType = "list-item"
+	NodeTypeHR                     NodeType = "hr"
+	NodeTypeQuote                  NodeType = "blockquote"
+	NodeTypeTable                  NodeType = "table"
+	NodeTypeTableRow               NodeType = "table-row"
+	NodeTypeTableCell              NodeType = "table-cell"
+	NodeTypeTableHeaderCell        NodeType = "table-header-cell"
+	NodeTypeEmbeddedEntryBlock     NodeType = "embedded-entry-block"
+	NodeTypeEmbeddedAssetBlock     NodeType = "embedded-asset-block"
+	NodeTypeEmbeddedResourceBlock  NodeType = "embedded-resource-block"
+	NodeTypeEmbeddedEntryInline    NodeType = "embedded-entry-inline"
+	NodeTypeEmbeddedResourceInline NodeType = "embedded-resource-inline"
+	NodeTypeHyperlink              NodeType = "hyperlink"
+	NodeTypeEntryHyperlink         NodeType = "entry-hyperlink"
+	NodeTypeAssetHyperlink         NodeType = "asset-hyperlink"
+	NodeTypeResourceHyperlink      NodeType = "resource-hyperlink"
+	NodeTypeText                   NodeType = "text"
+)
+
+// MarkType is the type of a text mark
+type MarkType string
+
+// Mark types
+const (
+	MarkBold          MarkType = "bold"
+	MarkItalic        MarkType = "italic"
+	MarkUnderline     MarkType = "underline"
+	MarkCode          MarkType = "code"
+	MarkSuperscript   MarkType = "superscript"
+	MarkSubscript     MarkType = "subscript"
+	MarkStrikethrough MarkType = "strikethrough"
+)
+
+// Headings are the heading node types by level
+var Headings = []NodeType{
+	NodeTypeHeading1,
+	NodeTypeHeading2,
+	NodeTypeHeading3,
+	NodeTypeHeading4,
+	NodeTypeHeading5,
+	NodeTypeHeading6,
+}
+
+var inlines = []NodeType{
+	NodeTypeText,
+	NodeTypeHyperlink,
+	NodeTypeEntryHyperlink,
+	NodeTypeAssetHyperlink,
+	NodeTypeResourceHyperlink,
+	NodeTypeEmbeddedEntryInline,
+	NodeTypeEmbeddedResourceInline,
+}
+
+// Node is a node of a rich text document. Text nodes have a value and marks,
+// all other nodes have content.
+type Node struct {
+	NodeType NodeType
+	Data     Data
+	Content  []*Node
+	Value    string
+	Marks    []Mark
+}
+
+// Mark is a formatting of a text node
+type Mark struct {
+	Type MarkType `json:"type"`
+}
+
+// Data holds the uri of hyperlinks and the target of embedded nodes and
+// entry, asset or resource hyperlinks
+type Data struct {
+	URI    string  `json:"uri,omitempty"`
+	Target *Target `json:"target,omitempty"`
+}
+
+// Target is a link to an entry, asset or resource. The JSON of targets
+// resolved to entries or assets is kept and available through Decode.
+type Target struct {
+	Sys TargetSys `json:"sys"`
+
+	raw json.RawMessage
+}
+
+// TargetSys identifies the target
+type TargetSys struct {
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type"`
+	LinkType string `json:"linkType,omitempty"`
+	URN      string `json:"urn,omitempty"`
+}
+
+// Parse decodes a document from JSON
+func Parse(data []byte) (*Node, error) {
+	var node *Node
+	if err := json.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	if node == nil || node.NodeType != NodeTypeDocument {
+		return nil, fmt.Errorf("rich text: expected a document")
+	}
+
+	return node, nil
+}
+
+// FromValue converts a decoded rich text field value, e.g. the
+// map[string]interface{} of entry fields, into a document
+func FromValue(value interface{}) (*Node, error) {
+	if node, ok := value.(*Node); ok {
+		return node, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(data)
+}
+
+// NewDocument returns a document with the given block nodes
+func NewDocument(content ...*Node) *Node {
+	return NewNode(NodeTypeDocument, content...)
+}
+
+// NewNode returns a node of the type with the given content
+func NewNode(nodeType NodeType, content ...*Node) *Node {
+	return &Node{NodeType: nodeType, Content: content}
+}
+
+// NewText returns a text node
+func NewText(value string, marks ...MarkType) *Node {
+	node := &Node{NodeType: NodeTypeText, Value: value}
+	for _, mark := range marks {
+		node.Marks = append(node.Marks, Mark{Type: mark})
+	}
+
+	return node
+}
+
+// NewHyperlink returns a hyperlink to the uri
+func NewHyperlink(uri string, content ...*Node) *Node {
+	node := NewNode(NodeTypeHyperlink, content...)
+	node.Data.URI = uri
+
+	return node
+}
+
+// NewLinkNode returns an embedded node or hyperlink of the type linking the
+// entry or asset with the given id, e.g.
+//
+//	richtext.NewLinkNode(richtext.NodeTypeEmbeddedAssetBlock, "Asset", "photo")
+func NewLinkNode(nodeType NodeType, linkType, id string, content ...*Node) *Node {
+	node := NewNode(nodeType, content...)
+	node.Data.Target = NewTarget(linkType, id)
+
+	return node
+}
+
+// NewTarget returns a link target
+func NewTarget(linkType, id string) *Target {
+	return &Target{Sys: TargetSys{ID: id, Type: "Link", LinkType: linkType}}
+}
+
+// IsInline reports whether the node is an inline node
+func (n *Node) IsInline() bool {
+	return slices.Contains(inlines, n.NodeType)
+}
+
+// HeadingLevel returns the level of a heading node, 0 for other nodes
+func (n *Node) HeadingLevel() int {
+	return slices.Index(Headings, n.NodeType) + 1
+}
+
+// Walk calls fn for the node and its descendants in document order. The
+// content of a node is skipped if fn returns false.
+func (n *Node) Walk(fn func(node *Node) bool) {
+	if n == nil || !fn(n) {
+		return
+	}
+	for _, child := range n.Content {
+		child.Walk(fn)
+	}
+}
+
+// HasMark reports whether a text node has the mark
+func (n *Node) HasMark(markType MarkType) bool {
+	return slices.ContainsFunc(n.Marks, func(mark Mark) bool {
+		return mark.Type == markType
+	})
+}
+
+// MarshalJSON writes text nodes with value and marks, all other nodes with
+// content as expected by the api
+func (n Node) MarshalJSON() ([]byte, error) {
+	if n.NodeType == NodeTypeText {
+		marks := n.Marks
+		if marks == nil {
+			marks = []Mark{}
+		}
+		return json.Marshal(struct {
+			NodeType NodeType `json:"nodeType"`
+			Value    string   `json:"value"`
+			Marks    []Mark   `json:"marks"`
+			Data     Data     `json:"data"`
+		}{n.NodeType, n.Value, marks, n.Data})
+	}
+
+	content := n.Content
+	if content == nil {
+		content = []*Node{}
+	}
+	return json.Marshal(struct {
+		NodeType NodeType `json:"nodeType"`
+		Data     Data     `json:"data"`
+		Content  []*Node  `json:"content"`
+	}{n.NodeType, n.Data, content})
+}
+
+// UnmarshalJSON decodes any node
+func (n *Node) UnmarshalJSON(data []byte) error {
+	var node struct {
+		NodeType NodeType `json:"nodeType"`
+		Data     Data     `json:"data"`
+		Content  []*Node  `json:"content"`
+		Value    string   `json:"value"`
+		Marks    []Mark   `json:"marks"`
+	}
+	if err := json.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	if node.NodeType == "" {
+		return fmt.Errorf("rich text: node without nodeType")
+	}
+
+	*n = Node(node)
+
+	return nil
+}
+
+// Resolved reports whether the target has been resolved to an entry or asset
+func (t *Target) Resolved() bool {
+	return t.raw != nil
+}
+
+// Decode decodes the target, e.g. into a contentful.Entry if it has been
+// resolved, or into a contentful.Link otherwise
+func (t *Target) Decode(v interface{}) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// MarshalJSON writes resolved targets as they were received
+func (t Target) MarshalJSON() ([]byte, error) {
+	if t.raw != nil {
+		return t.raw, nil
+	}
+
+	type target Target
+	return json.Marshal(target(t))
+}
+
+// UnmarshalJSON decodes links and keeps the JSON of resolved targets
+func (t *Target) UnmarshalJSON(data []byte) error {
+	type target Target
+	var v target
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*t = Target(v)
+	if t.Sys.Type != "Link" {
+		t.raw = bytes.Clone(data)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package richtext
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const documentJSON = `{
+	"nodeType": "document",
+	"data": {},
+	"content": [
+		{"nodeType": "heading-2", "data": {}, "content": [
+			{"nodeType": "text", "value": "Cats", "marks": [], "data": {}}
+		]},
+		{"nodeType": "paragraph", "data": {}, "content": [
+			{"nodeType": "text", "value": "Nyan ", "marks": [], "data": {}},
+			{"nodeType": "text", "value": "cat", "marks": [{"type": "bold"}, {"type": "italic"}], "data": {}},
+			{"nodeType": "text", "value": " & ", "marks": [], "data": {}},
+			{"nodeType": "hyperlink", "data": {"uri": "https://example.com?a=1&b=2"}, "content": [
+				{"nodeType": "text", "value": "friends", "marks": [], "data": {}}
+			]},
+			{"nodeType": "embedded-entry-inline", "data": {"target": {"sys": {"id": "happycat", "type": "Link", "linkType": "Entry"}}}, "content": []}
+		]},
+		{"nodeType": "unordered-list", "data": {}, "content": [
+			{"nodeType": "list-item", "data": {}, "content": [
+				{"nodeType": "paragraph", "data": {}, "content": [{"nodeType": "text", "value": "one", "marks": [], "data": {}}]},
+				{"nodeType": "ordered-list", "data": {}, "content": [
+					{"nodeType": "list-item", "data": {}, "content": [
+						{"nodeType": "paragraph", "data": {}, "content": [{"nodeType": "text", "value": "nested", "marks": [], "data": {}}]}
+					]}
+				]}
+			]},
+			{"nodeType": "list-item", "data": {}, "content": [
+				{"nodeType": "paragraph", "data": {}, "content": [{"nodeType": "text", "value": "two", "marks": [{"type": "code"}], "data": {}}]}
+			]}
+		]},
+		{"nodeType": "blockquote", "data": {}, "content": [
+			{"nodeType": "paragraph", "data": {}, "content": [{"nodeType": "text", "value": "meow", "marks": [], "data": {}}]}
+		]},
+		{"nodeType": "hr", "data": {}, "content": []},
+		{"nodeType": "embedded-asset-block", "data": {"target": {"sys": {"id": "photo", "type": "Asset"}, "fields": {"title": "Photo"}}}, "content": []},
+		{"nodeType": "table", "data": {}, "content": [
+			{"nodeType": "table-row", "data": {}, "content": [
+				{"nodeType": "table-header-cell", "data": {}, "content": [{"nodeType": "paragraph", "data": {}, "content": [{"nodeType": "text", "value": "Name", "marks": [], "data": {}}]}]},
+				{"nodeType": "table-header-cell", "data": {}, "content": [{"nodeType": "paragraph", "data": {}, "content": [{"nodeType": "text", "value": "Lives", "marks": [], "data": {}}]}]}
+			]},
+			{"nodeType": "table-row", "data": {}, "content": [
+				{"nodeType": "table-cell", "data": {}, "content": [{"nodeType": "paragraph", "data": {}, "content": [{"nodeType": "text", "value": "Nyan", "marks": [], "data": {}}]}]},
+				{"nodeType": "table-cell", "data": {}, "content": [{"nodeType": "paragraph", "data": {}, "content": [{"nodeType": "text", "value": "9", "marks": [], "data": {}}]}]}
+			]}
+		]}
+	]
+}`
+
+func TestParse(t *testing.T) {
+	doc, err := Parse([]byte(documentJSON))
+	require.NoError(t, err)
+	require.Len(t, doc.Content, 7)
+
+	assert.Equal(t, 2, doc.Content[0].HeadingLevel())
+	paragraph := doc.Content[1]
+	assert.True(t, paragraph.Content[1].HasMark(MarkItalic))
+	assert.Equal(t, "https://example.com?a=1&b=2", paragraph.Content[3].Data.URI)
+	assert.True(t, paragraph.Content[4].IsInline())
+	assert.Equal(t, "happycat", paragraph.Content[4].Data.Target.Sys.ID)
+	assert.False(t, paragraph.Content[4].Data.Target.Resolved())
+
+	// round trip
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.JSONEq(t, documentJSON, string(data))
+
+	_, err = Parse([]byte(`{"nodeType": "paragraph", "content": []}`))
+	assert.Error(t, err)
+	_, err = Parse([]byte(`{"content": []}`))
+	assert.Error(t, err)
+}
+
+func TestTargetResolved(t *testing.T) {
+	doc, err := Parse([]byte(documentJSON))
+	require.NoError(t, err)
+
+	target := doc.Content[5].Data.Target
+	require.True(t, target.Resolved())
+
+	var asset struct {
+		Fields map[string]string `json:"fields"`
+	}
+	require.NoError(t, target.Decode(&asset))
+	assert.Equal(t, "Photo", asset.Fields["title"])
+}
+
+func TestFromValue(t *testing.T) {
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(documentJSON), &value))
+
+	doc, err := FromValue(value)
+	require.NoError(t, err)
+	assert.Equal(t, NodeTypeDocument, doc.NodeType)
+
+	same, err := FromValue(doc)
+	require.NoError(t, err)
+	assert.Same(t, doc, same)
+}
+
+func TestNewDocument(t *testing.T) {
+	doc := NewDocument(
+		NewNode(NodeTypeParagraph,
+			NewText("Hello", MarkBold),
+			NewHyperlink("https://example.com", NewText("link")),
+		),
+		NewLinkNode(NodeTypeEmbeddedEntryBlock, "Entry", "cat"),
+	)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"nodeType": "document", "data": {}, "content": [
+			{"nodeType": "paragraph", "data": {}, "content": [
+				{"nodeType": "text", "value": "Hello", "marks": [{"type": "bold"}], "data": {}},
+				{"nodeType": "hyperlink", "data": {"uri": "https://example.com"}, "content": [
+					{"nodeType": "text", "value": "link", "marks": [], "data": {}}
+				]}
+			]},
+			{"nodeType": "embedded-entry-block", "data": {"target": {"sys": {"id": "cat", "type": "Link", "linkType": "Entry"}}}, "content": []}
+		]
+	}`, string(data))
+
+	var texts []string
+	doc.Walk(func(node *Node) bool {
+		if node.NodeType == NodeTypeText {
+			texts = append(texts, node.Value)
+		}
+		return node.NodeType != NodeTypeHyperlink
+	})
+	assert.Equal(t, []string{"Hello"}, texts)
+}
@@ -0,0 +1,121 @@
+package contentful
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// RolesService service
+type RolesService service
+
+// Role model
+type Role struct {
+	Sys         *Sys                   `json:"sys,omitempty"`
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Policies    []*RolePolicy          `json:"policies"`
+	Permissions map[string]interface{} `json:"permissions"`
+}
+
+// RolePolicy model. Actions is either "all" or a list of actions.
+type RolePolicy struct {
+	Effect     string                 `json:"effect"`
+	Actions    interface{}            `json:"actions"`
+	Constraint map[string]interface{} `json:"constraint,omitempty"`
+}
+
+// GetVersion returns entity version
+func (role *Role) GetVersion() int {
+	version := 1
+	if role.Sys != nil {
+		version = role.Sys.Version
+	}
+
+	return version
+}
+
+// List returns the roles collection of the space
+func (service *RolesService) List(ctx context.Context, spaceID string) *Collection[Role] {
+	path := fmt.Sprintf("/spaces/%s/roles", spaceID)
+	method := http.MethodGet
+
+	ctx = service.c.operation(ctx, "Roles", "List", spaceID, "")
+	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
+	if err != nil {
+		return &Collection[Role]{}
+	}
+
+	col := NewCollection[Role](&CollectionOptions{})
+	col.c = service.c
+	col.req = req
+
+	return col
+}
+
+// Get returns a single role entity
+func (service *RolesService) Get(ctx context.Context, spaceID, roleID string) (*Role, error) {
+	path := fmt.Sprintf("/spaces/%s/roles/%s", spaceID, roleID)
+	method := http.MethodGet
+
+	ctx = service.c.operation(ctx, "Roles", "Get", spaceID, roleID)
+	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var role *Role
+	if err := service.c.do(req, &role); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// Upsert updates or creates a new role entity
+func (service *RolesService) Upsert(ctx context.Context, spaceID string, role *Role) error {
+	bytesArray, err := Marshal(role)
+	if err != nil {
+		return err
+	}
+
+	var path string
+	var method string
+
+	if role.Sys != nil && role.Sys.ID != "" {
+		path = fmt.Sprintf("/spaces/%s/roles/%s", spaceID, role.Sys.ID)
+		method = http.MethodPut
+	} else {
+		path = fmt.Sprintf("/spaces/%s/roles", spaceID)
+		method = http.MethodPost
+	}
+
+	ctx = service.c.operation(ctx, "Roles", "Upsert", spaceID, sysID(role.Sys))
+	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Contentful-Version", strconv.Itoa(role.GetVersion()))
+
+	return service.c.do(req, role)
+}
+
+// Delete the role
+func (service *RolesService) Delete(ctx context.Context, spaceID string, role *Role) error {
+	path := fmt.Sprintf("/spaces/%s/roles/%s", spaceID, role.Sys.ID)
+	method := http.MethodDelete
+
+	ctx = service.c.operation(ctx, "Roles", "Delete", spaceID, role.Sys.ID)
+	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	version := strconv.Itoa(role.Sys.Version)
+	req.Header.Set("X-Contentful-Version", version)
+
+	return service.c.do(req, nil)
+}
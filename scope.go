@@ -0,0 +1,466 @@
+package contentful
+
+import (
+	"context"
+	"io"
+	"slices"
+)
+
+// SpaceClient is a client handle bound to a space
+type SpaceClient struct {
+	c       *Contentful
+	spaceID string
+
+	APIKeys      *SpaceAPIKeysService
+	Aliases      *SpaceEnvironmentAliasesService
+	Environments *SpaceEnvironmentsService
+	Roles        *SpaceRolesService
+	Webhooks     *SpaceWebhooksService
+}
+
+// EnvironmentClient is a client handle bound to a space and an environment.
+// Handles for different environments can be used concurrently.
+type EnvironmentClient struct {
+	c             *Contentful
+	spaceID       string
+	environmentID string
+
+	Assets           *EnvironmentAssetsService
+	ContentTypes     *EnvironmentContentTypesService
+	EditorInterfaces *EnvironmentEditorInterfacesService
+	Entries          *EnvironmentEntriesService
+	Locales          *EnvironmentLocalesService
+	Tags             *EnvironmentTagsService
+	Upload           *EnvironmentUploadService
+}
+
+// Space returns a handle bound to the given space. An empty spaceID selects
+// the space configured with WithSpace.
+func (c *Contentful) Space(spaceID string) *SpaceClient {
+	if spaceID == "" {
+		spaceID = c.spaceID
+	}
+
+	return &SpaceClient{
+		c:            c,
+		spaceID:      spaceID,
+		APIKeys:      &SpaceAPIKeysService{s: c.APIKeys, spaceID: spaceID},
+		Aliases:      &SpaceEnvironmentAliasesService{s: c.Aliases, spaceID: spaceID},
+		Environments: &SpaceEnvironmentsService{s: c.Environments, spaceID: spaceID},
+		Roles:        &SpaceRolesService{s: c.Roles, spaceID: spaceID},
+		Webhooks:     &SpaceWebhooksService{s: c.Webhooks, spaceID: spaceID},
+	}
+}
+
+// ID returns the space id
+func (s *SpaceClient) ID() string {
+	return s.spaceID
+}
+
+// Environment returns a handle bound to the given environment of the space.
+// An empty environmentID selects the environment of the client.
+func (s *SpaceClient) Environment(environmentID string) *EnvironmentClient {
+	if environmentID == "" {
+		environmentID = s.c.Environment
+	}
+
+	// shallow copy sharing the http client, limiter and middlewares. The
+	// chains are clipped, so Use on either client does not append into the
+	// backing array of the other.
+	c := *s.c
+	c.Environment = environmentID
+	c.middlewares = slices.Clip(c.middlewares)
+	c.hooks = slices.Clip(c.hooks)
+	c.wire()
+
+	return &EnvironmentClient{
+		c:                &c,
+		spaceID:          s.spaceID,
+		environmentID:    environmentID,
+		Assets:           &EnvironmentAssetsService{s: c.Assets, spaceID: s.spaceID},
+		ContentTypes:     &EnvironmentContentTypesService{s: c.ContentTypes, spaceID: s.spaceID},
+		EditorInterfaces: &EnvironmentEditorInterfacesService{s: c.EditorInterfaces, spaceID: s.spaceID},
+		Entries:          &EnvironmentEntriesService{s: c.Entries, spaceID: s.spaceID},
+		Locales:          &EnvironmentLocalesService{s: c.Locales, spaceID: s.spaceID},
+		Tags:             &EnvironmentTagsService{s: c.Tags, spaceID: s.spaceID},
+		Upload:           &EnvironmentUploadService{s: c.Upload, spaceID: s.spaceID},
+	}
+}
+
+// ID returns the environment id
+func (e *EnvironmentClient) ID() string {
+	return e.environmentID
+}
+
+// SpaceID returns the space id
+func (e *EnvironmentClient) SpaceID() string {
+	return e.spaceID
+}
+
+// Client returns a client bound to the environment, e.g. to be used with
+// NewContentTypeService
+func (e *EnvironmentClient) Client() *Contentful {
+	return e.c
+}
+
+// WaitReady waits until the environment is ready to be used
+func (e *EnvironmentClient) WaitReady(ctx context.Context) (*Environment, error) {
+	return e.c.Environments.WaitReady(ctx, e.spaceID, e.environmentID)
+}
+
+// SpaceAPIKeysService is the APIKeyService bound to a space
+type SpaceAPIKeysService struct {
+	s       *APIKeyService
+	spaceID string
+}
+
+// List returns all api keys collection
+func (service *SpaceAPIKeysService) List(ctx context.Context) *Collection[APIKey] {
+	return service.s.List(ctx, service.spaceID)
+}
+
+// Get returns a single api key entity
+func (service *SpaceAPIKeysService) Get(ctx context.Context, apiKeyID string) (*APIKey, error) {
+	return service.s.Get(ctx, service.spaceID, apiKeyID)
+}
+
+// Upsert updates or creates a new api key entity
+func (service *SpaceAPIKeysService) Upsert(ctx context.Context, apiKey *APIKey) error {
+	return service.s.Upsert(ctx, service.spaceID, apiKey)
+}
+
+// Delete deletes a sinlge api key entity
+func (service *SpaceAPIKeysService) Delete(ctx context.Context, apiKey *APIKey) error {
+	return service.s.Delete(ctx, service.spaceID, apiKey)
+}
+
+// SpaceEnvironmentAliasesService is the EnvironmentAliasesService bound to a space
+type SpaceEnvironmentAliasesService struct {
+	s       *EnvironmentAliasesService
+	spaceID string
+}
+
+// List returns an environment aliases collection
+func (service *SpaceEnvironmentAliasesService) List(ctx context.Context) *Collection[EnvironmentAlias] {
+	return service.s.List(ctx, service.spaceID)
+}
+
+// Get returns a single environment alias entity
+func (service *SpaceEnvironmentAliasesService) Get(ctx context.Context, aliasID string) (*EnvironmentAlias, error) {
+	return service.s.Get(ctx, service.spaceID, aliasID)
+}
+
+// Create creates a new optional alias
+func (service *SpaceEnvironmentAliasesService) Create(ctx context.Context, alias *EnvironmentAlias) error {
+	return service.s.Create(ctx, service.spaceID, alias)
+}
+
+// Update points the alias to alias.Environment
+func (service *SpaceEnvironmentAliasesService) Update(ctx context.Context, alias *EnvironmentAlias) error {
+	return service.s.Update(ctx, service.spaceID, alias)
+}
+
+// Delete the optional alias
+func (service *SpaceEnvironmentAliasesService) Delete(ctx context.Context, alias *EnvironmentAlias) error {
+	return service.s.Delete(ctx, service.spaceID, alias)
+}
+
+// Switch migrates a new environment and points the alias to it
+func (service *SpaceEnvironmentAliasesService) Switch(ctx context.Context, aliasID string, opts SwitchAliasOptions) (*EnvironmentAlias, error) {
+	return service.s.Switch(ctx, service.spaceID, aliasID, opts)
+}
+
+// SpaceEnvironmentsService is the EnvironmentsService bound to a space
+type SpaceEnvironmentsService struct {
+	s       *EnvironmentsService
+	spaceID string
+}
+
+// List returns an environments collection
+func (service *SpaceEnvironmentsService) List(ctx context.Context) *Collection[Environment] {
+	return service.s.List(ctx, service.spaceID)
+}
+
+// Get returns a single environment entity
+func (service *SpaceEnvironmentsService) Get(ctx context.Context, environmentID string) (*Environment, error) {
+	return service.s.Get(ctx, service.spaceID, environmentID)
+}
+
+// Create creates a new environment cloned from sourceEnvironmentID
+func (service *SpaceEnvironmentsService) Create(ctx context.Context, environment *Environment, sourceEnvironmentID string) error {
+	return service.s.Create(ctx, service.spaceID, environment, sourceEnvironmentID)
+}
+
+// Update updates the name of the environment
+func (service *SpaceEnvironmentsService) Update(ctx context.Context, environment *Environment) error {
+	return service.s.Update(ctx, service.spaceID, environment)
+}
+
+// Delete the environment
+func (service *SpaceEnvironmentsService) Delete(ctx context.Context, environment *Environment) error {
+	return service.s.Delete(ctx, service.spaceID, environment)
+}
+
+// WaitReady polls the environment until its status is ready
+func (service *SpaceEnvironmentsService) WaitReady(ctx context.Context, environmentID string) (*Environment, error) {
+	return service.s.WaitReady(ctx, service.spaceID, environmentID)
+}
+
+// SpaceRolesService is the RolesService bound to a space
+type SpaceRolesService struct {
+	s       *RolesService
+	spaceID string
+}
+
+// List returns the roles collection
+func (service *SpaceRolesService) List(ctx context.Context) *Collection[Role] {
+	return service.s.List(ctx, service.spaceID)
+}
+
+// Get returns a single role entity
+func (service *SpaceRolesService) Get(ctx context.Context, roleID string) (*Role, error) {
+	return service.s.Get(ctx, service.spaceID, roleID)
+}
+
+// Upsert updates or creates a new role entity
+func (service *SpaceRolesService) Upsert(ctx context.Context, role *Role) error {
+	return service.s.Upsert(ctx, service.spaceID, role)
+}
+
+// Delete the role
+func (service *SpaceRolesService) Delete(ctx context.Context, role *Role) error {
+	return service.s.Delete(ctx, service.spaceID, role)
+}
+
+// SpaceWebhooksService is the WebhooksService bound to a space
+type SpaceWebhooksService struct {
+	s       *WebhooksService
+	spaceID string
+}
+
+// List returns webhooks collection
+func (service *SpaceWebhooksService) List(ctx context.Context) *Collection[Webhook] {
+	return service.s.List(ctx, service.spaceID)
+}
+
+// Get returns a single webhook entity
+func (service *SpaceWebhooksService) Get(ctx context.Context, webhookID string) (*Webhook, error) {
+	return service.s.Get(ctx, service.spaceID, webhookID)
+}
+
+// Upsert updates or creates a new entity
+func (service *SpaceWebhooksService) Upsert(ctx context.Context, webhook *Webhook) error {
+	return service.s.Upsert(ctx, service.spaceID, webhook)
+}
+
+// Delete the webhook
+func (service *SpaceWebhooksService) Delete(ctx context.Context, webhook *Webhook) error {
+	return service.s.Delete(ctx, service.spaceID, webhook)
+}
+
+// EnvironmentAssetsService is the AssetsService bound to an environment
+type EnvironmentAssetsService struct {
+	s       *AssetsService
+	spaceID string
+}
+
+// List returns asset collection
+func (service *EnvironmentAssetsService) List(ctx context.Context) *Collection[Asset] {
+	return service.s.List(ctx, service.spaceID)
+}
+
+// Get returns a single asset entity
+func (service *EnvironmentAssetsService) Get(ctx context.Context, assetID string, locale ...string) (*Asset, error) {
+	return service.s.Get(ctx, service.spaceID, assetID, locale...)
+}
+
+// Upsert updates or creates a new asset entity
+func (service *EnvironmentAssetsService) Upsert(ctx context.Context, asset *Asset) error {
+	return service.s.Upsert(ctx, service.spaceID, asset)
+}
+
+// Delete sends delete request
+func (service *EnvironmentAssetsService) Delete(ctx context.Context, asset *Asset) error {
+	return service.s.Delete(ctx, service.spaceID, asset)
+}
+
+// Process the asset
+func (service *EnvironmentAssetsService) Process(ctx context.Context, asset *Asset) error {
+	return service.s.Process(ctx, service.spaceID, asset)
+}
+
+// Publish publishes the asset
+func (service *EnvironmentAssetsService) Publish(ctx context.Context, asset *Asset) error {
+	return service.s.Publish(ctx, service.spaceID, asset)
+}
+
+// Unpublish unpublishes the asset
+func (service *EnvironmentAssetsService) Unpublish(ctx context.Context, asset *Asset) error {
+	return service.s.Unpublish(ctx, service.spaceID, asset)
+}
+
+// EnvironmentContentTypesService is the ContentTypesService bound to an environment
+type EnvironmentContentTypesService struct {
+	s       *ContentTypesService
+	spaceID string
+}
+
+// List return a content type collection
+func (service *EnvironmentContentTypesService) List(ctx context.Context) *Collection[ContentType] {
+	return service.s.List(ctx, service.spaceID)
+}
+
+// Get fetched a content type specified by `contentTypeID`
+func (service *EnvironmentContentTypesService) Get(ctx context.Context, contentTypeID string) (*ContentType, error) {
+	return service.s.Get(ctx, service.spaceID, contentTypeID)
+}
+
+// Upsert updates or creates a new content type
+func (service *EnvironmentContentTypesService) Upsert(ctx context.Context, ct *ContentType) error {
+	return service.s.Upsert(ctx, service.spaceID, ct)
+}
+
+// Delete the content_type
+func (service *EnvironmentContentTypesService) Delete(ctx context.Context, ct *ContentType) error {
+	return service.s.Delete(ctx, service.spaceID, ct)
+}
+
+// Activate the contenttype, a.k.a publish
+func (service *EnvironmentContentTypesService) Activate(ctx context.Context, ct *ContentType) error {
+	return service.s.Activate(ctx, service.spaceID, ct)
+}
+
+// Deactivate the contenttype, a.k.a unpublish
+func (service *EnvironmentContentTypesService) Deactivate(ctx context.Context, ct *ContentType) error {
+	return service.s.Deactivate(ctx, service.spaceID, ct)
+}
+
+// EnvironmentEditorInterfacesService is the EditorInterfacesService bound to an environment
+type EnvironmentEditorInterfacesService struct {
+	s       *EditorInterfacesService
+	spaceID string
+}
+
+// List returns the editor interfaces of all content types
+func (service *EnvironmentEditorInterfacesService) List(ctx context.Context) *Collection[EditorInterface] {
+	return service.s.List(ctx, service.spaceID)
+}
+
+// Get returns the editor interface of a content type
+func (service *EnvironmentEditorInterfacesService) Get(ctx context.Context, contentTypeID string) (*EditorInterface, error) {
+	return service.s.Get(ctx, service.spaceID, contentTypeID)
+}
+
+// Update updates the editor interface of a content type
+func (service *EnvironmentEditorInterfacesService) Update(ctx context.Context, ei *EditorInterface) error {
+	return service.s.Update(ctx, service.spaceID, ei)
+}
+
+// EnvironmentEntriesService is the EntriesService bound to an environment
+type EnvironmentEntriesService struct {
+	s       *EntriesService
+	spaceID string
+}
+
+// GetEntryKey returns the entry's keys
+func (service *EnvironmentEntriesService) GetEntryKey(ctx context.Context, entry *Entry, key string) (*EntryField, error) {
+	return service.s.GetEntryKey(ctx, entry, key)
+}
+
+// List returns entries collection
+func (service *EnvironmentEntriesService) List(ctx context.Context) *Collection[Entry] {
+	return service.s.List(ctx, service.spaceID)
+}
+
+// Sync returns entries collection
+func (service *EnvironmentEntriesService) Sync(ctx context.Context, initial bool, syncToken ...string) *Collection[Entry] {
+	return service.s.Sync(ctx, service.spaceID, initial, syncToken...)
+}
+
+// Get returns a single entry
+func (service *EnvironmentEntriesService) Get(ctx context.Context, entryID string, locale ...string) (*Entry, error) {
+	return service.s.Get(ctx, service.spaceID, entryID, locale...)
+}
+
+// Delete the entry
+func (service *EnvironmentEntriesService) Delete(ctx context.Context, entryID string) error {
+	return service.s.Delete(ctx, service.spaceID, entryID)
+}
+
+// Upsert updates or creates a new entry
+func (service *EnvironmentEntriesService) Upsert(ctx context.Context, entry *Entry) error {
+	return service.s.Upsert(ctx, service.spaceID, entry)
+}
+
+// Publish the entry
+func (service *EnvironmentEntriesService) Publish(ctx context.Context, entry *Entry) error {
+	return service.s.Publish(ctx, service.spaceID, entry)
+}
+
+// Unpublish the entry
+func (service *EnvironmentEntriesService) Unpublish(ctx context.Context, entry *Entry) error {
+	return service.s.Unpublish(ctx, service.spaceID, entry)
+}
+
+// Archive the entry
+func (service *EnvironmentEntriesService) Archive(ctx context.Context, entry *Entry) error {
+	return service.s.Archive(ctx, service.spaceID, entry)
+}
+
+// EnvironmentLocalesService is the LocalesService bound to an environment
+type EnvironmentLocalesService struct {
+	s       *LocalesService
+	spaceID string
+}
+
+// List returns a locales collection
+func (service *EnvironmentLocalesService) List(ctx context.Context) *Collection[Locale] {
+	return service.s.List(ctx, service.spaceID)
+}
+
+// Get returns a single locale entity
+func (service *EnvironmentLocalesService) Get(ctx context.Context, localeID string) (*Locale, error) {
+	return service.s.Get(ctx, service.spaceID, localeID)
+}
+
+// Delete the locale
+func (service *EnvironmentLocalesService) Delete(ctx context.Context, locale *Locale) error {
+	return service.s.Delete(ctx, service.spaceID, locale)
+}
+
+// Upsert updates or creates a new locale entity
+func (service *EnvironmentLocalesService) Upsert(ctx context.Context, locale *Locale) error {
+	return service.s.Upsert(ctx, service.spaceID, locale)
+}
+
+// EnvironmentTagsService is the TagsService bound to an environment
+type EnvironmentTagsService struct {
+	s       *TagsService
+	spaceID string
+}
+
+// List returns tags collection
+func (service *EnvironmentTagsService) List(ctx context.Context) *Collection[Tag] {
+	return service.s.List(ctx, service.spaceID)
+}
+
+// Get returns a single tag
+func (service *EnvironmentTagsService) Get(ctx context.Context, tagID string, locale ...string) (*Tag, error) {
+	return service.s.Get(ctx, service.spaceID, tagID, locale...)
+}
+
+// Upsert updates or creates a tag with its id
+func (service *EnvironmentTagsService) Upsert(ctx context.Context, tag *Tag) error {
+	return service.s.Upsert(ctx, service.spaceID, tag)
+}
+
+// EnvironmentUploadService is the UploadService bound to an environment
+type EnvironmentUploadService struct {
+	s       *UploadService
+	spaceID string
+}
+
+// Uploads creates a new upload and returns a reference ID
+func (service *EnvironmentUploadService) Uploads(ctx context.Context, file io.Reader) (*Upload, error) {
+	return service.s.Uploads(ctx, service.spaceID, file)
+}
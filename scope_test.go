@@ -0,0 +1,107 @@
+package contentful
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpaceEnvironment(t *testing.T) {
+	var mu sync.Mutex
+	paths := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths[r.URL.Path]++
+		mu.Unlock()
+		_, _ = w.Write([]byte(readTestData(t, "spaces-id1-entries-nyancat.json")))
+	}))
+	defer server.Close()
+
+	c, err := New(APICMA, CMAToken, WithBaseURL(server.URL), WithEnvironment("master"))
+	require.NoError(t, err)
+
+	space := c.Space(spaceID)
+	master := space.Environment("")
+	feature := space.Environment("feature")
+	assert.Equal(t, spaceID, space.ID())
+	assert.Equal(t, "master", master.ID())
+	assert.Equal(t, "feature", feature.ID())
+	assert.Equal(t, spaceID, feature.SpaceID())
+	assert.Equal(t, "feature", feature.Client().Environment)
+	assert.Equal(t, "master", c.Environment)
+
+	var wg sync.WaitGroup
+	for _, env := range []*EnvironmentClient{master, feature} {
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				entry, err := env.Entries.Get(t.Context(), "nyancat")
+				assert.NoError(t, err)
+				assert.Equal(t, "nyancat", entry.Sys.ID)
+			}()
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, map[string]int{
+		"/spaces/id1/environments/master/entries/nyancat":  5,
+		"/spaces/id1/environments/feature/entries/nyancat": 5,
+	}, paths)
+}
+
+func TestSpaceDefault(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_, _ = w.Write([]byte(readTestData(t, "webhook.json")))
+	}))
+	defer server.Close()
+
+	c, err := New(APICMA, CMAToken, WithBaseURL(server.URL), WithSpace(spaceID))
+	require.NoError(t, err)
+
+	_, err = c.Space("").Webhooks.Get(t.Context(), "7fstd9fZ9T2p3kwD49FxhI")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(path, "/spaces/id1/webhook_definitions/"))
+}
+
+func TestSpaceEnvironmentMiddlewares(t *testing.T) {
+	var headers []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Values("X-Middleware")
+		_, _ = w.Write([]byte(readTestData(t, "spaces-id1-entries-nyancat.json")))
+	}))
+	defer server.Close()
+
+	header := func(value string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				req.Header.Add("X-Middleware", value)
+				return next.Do(req)
+			})
+		}
+	}
+
+	c, err := New(APICMA, CMAToken, WithBaseURL(server.URL), WithMiddleware(header("new"), header("new"), header("new")))
+	require.NoError(t, err)
+	// the chain has spare capacity now
+	c.Use(header("use"))
+
+	env := c.Space(spaceID).Environment("feature")
+	env.Client().Use(header("environment"))
+	c.Use(header("client"))
+
+	_, err = env.Entries.Get(t.Context(), "nyancat")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new", "new", "new", "use", "environment"}, headers)
+
+	_, err = c.Entries.Get(t.Context(), spaceID, "nyancat")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"new", "new", "new", "use", "client"}, headers)
+}
@@ -42,6 +42,7 @@ func (space *Space) GetVersion() int {
 
 // List creates a spaces collection
 func (service *SpacesService) List(ctx context.Context) *Collection[Space] {
+	ctx = service.c.operation(ctx, "Spaces", "List", "", "")
 	req, _ := service.c.newRequest(ctx, http.MethodGet, "/spaces", nil, nil, nil)
 
 	col := NewCollection[Space](&CollectionOptions{})
@@ -56,6 +57,7 @@ func (service *SpacesService) Get(ctx context.Context, spaceID string) (*Space,
 	path := fmt.Sprintf("/spaces/%s", spaceID)
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Spaces", "Get", spaceID, spaceID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return nil, err
@@ -87,6 +89,7 @@ func (service *SpacesService) Upsert(ctx context.Context, space *Space) error {
 		method = "POST"
 	}
 
+	ctx = service.c.operation(ctx, "Spaces", "Upsert", sysID(space.Sys), sysID(space.Sys))
 	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
 	if err != nil {
 		return err
@@ -102,6 +105,7 @@ func (service *SpacesService) Delete(ctx context.Context, space *Space) error {
 	path := fmt.Sprintf("/spaces/%s", space.Sys.ID)
 	method := http.MethodDelete
 
+	ctx = service.c.operation(ctx, "Spaces", "Delete", space.Sys.ID, space.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -0,0 +1,205 @@
+package contentful
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// SyncEventType is the type of a SyncEvent
+type SyncEventType string
+
+const (
+	// SyncEntryUpserted an entry has been created or updated
+	SyncEntryUpserted SyncEventType = "entry.upserted"
+
+	// SyncEntryDeleted an entry has been deleted or unpublished
+	SyncEntryDeleted SyncEventType = "entry.deleted"
+
+	// SyncAssetUpserted an asset has been created or updated
+	SyncAssetUpserted SyncEventType = "asset.upserted"
+
+	// SyncAssetDeleted an asset has been deleted or unpublished
+	SyncAssetDeleted SyncEventType = "asset.deleted"
+)
+
+// SyncEvent is a change reported by the sync api. Entry or Asset is set for
+// upserts, Sys identifies the changed entity for all events.
+type SyncEvent struct {
+	Type  SyncEventType `json:"type"`
+	Sys   *Sys          `json:"sys"`
+	Entry *Entry        `json:"entry,omitempty"`
+	Asset *Asset        `json:"asset,omitempty"`
+}
+
+// SyncStore persists the synced content and the token to continue with
+type SyncStore interface {
+	// Token returns the token of the last sync, empty if nothing was synced
+	Token(ctx context.Context) (string, error)
+	// Apply persists the events of a sync page together with the token of
+	// the next page
+	Apply(ctx context.Context, token string, events []SyncEvent) error
+}
+
+// Syncer keeps a SyncStore up to date using the sync api. The first call of
+// Sync performs the initial sync, later calls the delta sync.
+type Syncer struct {
+	c       *Contentful
+	spaceID string
+	store   SyncStore
+
+	// running serializes Sync calls
+	running       sync.Mutex
+	mu            sync.Mutex
+	subscriptions []*syncSubscription
+}
+
+// NewSyncer returns a syncer for the space and the environment of the client
+func NewSyncer(c *Contentful, spaceID string, store SyncStore) *Syncer {
+	return &Syncer{
+		c:       c,
+		spaceID: spaceID,
+		store:   store,
+	}
+}
+
+// Syncer returns a syncer for the environment
+func (e *EnvironmentClient) Syncer(store SyncStore) *Syncer {
+	return NewSyncer(e.c, e.spaceID, store)
+}
+
+// Sync fetches all pages of changes since the last sync. The events of every
+// page are passed to fn, which can be nil, and applied to the store together
+// with the token of the next page. If fn fails, the page is not applied and
+// its events are delivered again by the next Sync.
+func (s *Syncer) Sync(ctx context.Context, fn func(ctx context.Context, event SyncEvent) error) error {
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	token, err := s.store.Token(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		page, err := s.page(ctx, token)
+		if err != nil {
+			return err
+		}
+
+		events := make([]SyncEvent, 0, len(page.Items))
+		for _, item := range page.Items {
+			event, err := newSyncEvent(item)
+			if err != nil {
+				return err
+			}
+			s.contentType(event)
+			events = append(events, event)
+		}
+
+		if fn != nil {
+			for _, event := range events {
+				if err := fn(ctx, event); err != nil {
+					return err
+				}
+			}
+		}
+		if err := s.publish(ctx, events); err != nil {
+			return err
+		}
+
+		next := page.NextPageURL
+		if next == "" {
+			next = page.NextSyncURL
+		}
+		if token, err = syncTokenFromURL(next); err != nil {
+			return err
+		}
+
+		if err := s.store.Apply(ctx, token, events); err != nil {
+			return err
+		}
+
+		if page.NextPageURL == "" {
+			return nil
+		}
+	}
+}
+
+// page fetches the changes of the token or the initial sync
+func (s *Syncer) page(ctx context.Context, token string) (*Collection[json.RawMessage], error) {
+	path := fmt.Sprintf("/spaces/%s%s/sync", s.spaceID, getEnvPath(s.c))
+	method := http.MethodGet
+
+	query := url.Values{}
+	if token == "" {
+		query.Set("initial", "true")
+	} else {
+		query.Set("sync_token", token)
+	}
+
+	ctx = s.c.operation(ctx, "Sync", "Sync", s.spaceID, "")
+	req, err := s.c.newRequest(ctx, method, path, query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var page *Collection[json.RawMessage]
+	if err := s.c.do(req, &page); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// newSyncEvent decodes a sync item by its sys type
+func newSyncEvent(item json.RawMessage) (SyncEvent, error) {
+	var event SyncEvent
+	var err error
+
+	var head struct {
+		Sys *Sys `json:"sys"`
+	}
+	if err := json.Unmarshal(item, &head); err != nil {
+		return event, err
+	}
+	if head.Sys == nil {
+		return event, fmt.Errorf("sync item without sys")
+	}
+	event.Sys = head.Sys
+
+	switch head.Sys.Type {
+	case "Entry":
+		event.Type = SyncEntryUpserted
+		err = json.Unmarshal(item, &event.Entry)
+	case "Asset":
+		event.Type = SyncAssetUpserted
+		err = json.Unmarshal(item, &event.Asset)
+	case "DeletedEntry":
+		event.Type = SyncEntryDeleted
+	case "DeletedAsset":
+		event.Type = SyncAssetDeleted
+	default:
+		err = fmt.Errorf("unknown sync item type %q", head.Sys.Type)
+	}
+
+	return event, err
+}
+
+// syncTokenFromURL returns the sync_token parameter of a next page or next sync url
+func syncTokenFromURL(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	token := u.Query().Get("sync_token")
+	if token == "" {
+		return "", fmt.Errorf("missing sync token in %q", rawURL)
+	}
+
+	return token, nil
+}
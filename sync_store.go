@@ -0,0 +1,261 @@
+package contentful
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// MemorySyncStore is a SyncStore keeping the content in memory
+type MemorySyncStore struct {
+	mu      sync.RWMutex
+	token   string
+	entries map[string]*Entry
+	assets  map[string]*Asset
+}
+
+// NewMemorySyncStore returns an empty MemorySyncStore
+func NewMemorySyncStore() *MemorySyncStore {
+	return &MemorySyncStore{
+		entries: map[string]*Entry{},
+		assets:  map[string]*Asset{},
+	}
+}
+
+// Token returns the token of the last sync
+func (s *MemorySyncStore) Token(ctx context.Context) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.token, nil
+}
+
+// Apply applies the events and stores the token
+func (s *MemorySyncStore) Apply(ctx context.Context, token string, events []SyncEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.apply(token, events)
+
+	return nil
+}
+
+// Entry returns the entry with the id or nil
+func (s *MemorySyncStore) Entry(id string) *Entry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.entries[id]
+}
+
+// Asset returns the asset with the id or nil
+func (s *MemorySyncStore) Asset(id string) *Asset {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.assets[id]
+}
+
+// Entries returns all entries ordered by id
+func (s *MemorySyncStore) Entries() []*Entry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]*Entry, 0, len(s.entries))
+	for _, id := range slices.Sorted(maps.Keys(s.entries)) {
+		entries = append(entries, s.entries[id])
+	}
+
+	return entries
+}
+
+// Assets returns all assets ordered by id
+func (s *MemorySyncStore) Assets() []*Asset {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	assets := make([]*Asset, 0, len(s.assets))
+	for _, id := range slices.Sorted(maps.Keys(s.assets)) {
+		assets = append(assets, s.assets[id])
+	}
+
+	return assets
+}
+
+func (s *MemorySyncStore) apply(token string, events []SyncEvent) {
+	for _, event := range events {
+		switch event.Type {
+		case SyncEntryUpserted:
+			s.entries[event.Sys.ID] = event.Entry
+		case SyncEntryDeleted:
+			delete(s.entries, event.Sys.ID)
+		case SyncAssetUpserted:
+			s.assets[event.Sys.ID] = event.Asset
+		case SyncAssetDeleted:
+			delete(s.assets, event.Sys.ID)
+		}
+	}
+	s.token = token
+}
+
+// FileSyncStore is a SyncStore appending the events to a JSON lines file. The
+// content is kept in memory and restored from the file when opened.
+type FileSyncStore struct {
+	*MemorySyncStore
+	path string
+	file *os.File
+}
+
+// fileSyncRecord is a line of the file, a page is persisted as its events
+// followed by the token
+type fileSyncRecord struct {
+	Event *SyncEvent `json:"event,omitempty"`
+	Token string     `json:"token,omitempty"`
+}
+
+// OpenFileSyncStore opens or creates the file and restores its content.
+// Events of a page which was not completely written are ignored.
+func OpenFileSyncStore(path string) (*FileSyncStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &FileSyncStore{
+		MemorySyncStore: NewMemorySyncStore(),
+		path:            path,
+		file:            file,
+	}
+
+	// offset of the line read and of the end of the last complete page
+	var offset, committed int64
+	var events []SyncEvent
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			// end of file, a partial last line is dropped
+			break
+		}
+
+		var record fileSyncRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+		offset += int64(len(line))
+		if record.Event != nil {
+			events = append(events, *record.Event)
+		} else {
+			s.apply(record.Token, events)
+			events = nil
+			committed = offset
+		}
+	}
+
+	// drop an incomplete page
+	if err := file.Truncate(committed); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Apply appends the events and the token to the file
+func (s *FileSyncStore) Apply(ctx context.Context, token string, events []SyncEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := s.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if err := writeSyncRecords(s.file, token, events); err != nil {
+		// remove the partially written page
+		_ = s.file.Truncate(info.Size())
+		return err
+	}
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+
+	s.apply(token, events)
+
+	return nil
+}
+
+// Compact rewrites the file to contain the current content only
+func (s *FileSyncStore) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := make([]SyncEvent, 0, len(s.entries)+len(s.assets))
+	for _, id := range slices.Sorted(maps.Keys(s.entries)) {
+		entry := s.entries[id]
+		events = append(events, SyncEvent{Type: SyncEntryUpserted, Sys: entry.Sys, Entry: entry})
+	}
+	for _, id := range slices.Sorted(maps.Keys(s.assets)) {
+		asset := s.assets[id]
+		events = append(events, SyncEvent{Type: SyncAssetUpserted, Sys: asset.Sys, Asset: asset})
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := writeSyncRecords(tmp, s.token, events); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(s.path, os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	_ = s.file.Close()
+	s.file = file
+
+	return nil
+}
+
+// Close closes the file
+func (s *FileSyncStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+func writeSyncRecords(file *os.File, token string, events []SyncEvent) error {
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for i := range events {
+		if err := encoder.Encode(fileSyncRecord{Event: &events[i]}); err != nil {
+			return err
+		}
+	}
+	if err := encoder.Encode(fileSyncRecord{Token: token}); err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
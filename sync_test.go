@@ -0,0 +1,140 @@
+package contentful
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSyncServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/spaces/id1/environments/master/sync", r.URL.Path)
+		query := r.URL.Query()
+		switch {
+		case query.Get("initial") == "true":
+			_, _ = w.Write([]byte(`{"sys":{"type":"Array"},"items":[
+				{"sys":{"type":"Entry","id":"cat","contentType":{"sys":{"id":"animal"}}},"fields":{"name":{"en-US":"Cat"}}},
+				{"sys":{"type":"Entry","id":"dog","contentType":{"sys":{"id":"animal"}}},"fields":{"name":{"en-US":"Dog"}}}
+			],"nextPageUrl":"https://cdn.contentful.com/spaces/id1/environments/master/sync?sync_token=page2"}`))
+		case query.Get("sync_token") == "page2":
+			_, _ = w.Write([]byte(`{"sys":{"type":"Array"},"items":[
+				{"sys":{"type":"Asset","id":"photo"},"fields":{"title":{"en-US":"Photo"}}}
+			],"nextSyncUrl":"https://cdn.contentful.com/spaces/id1/environments/master/sync?sync_token=delta1"}`))
+		case query.Get("sync_token") == "delta1":
+			_, _ = w.Write([]byte(`{"sys":{"type":"Array"},"items":[
+				{"sys":{"type":"DeletedEntry","id":"dog"}},
+				{"sys":{"type":"DeletedAsset","id":"photo"}}
+			],"nextSyncUrl":"https://cdn.contentful.com/spaces/id1/environments/master/sync?sync_token=delta2"}`))
+		default:
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+	}))
+}
+
+func TestSyncerSync(t *testing.T) {
+	server := newSyncServer(t)
+	defer server.Close()
+
+	c, err := New(APICDA, CMAToken, WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	store := NewMemorySyncStore()
+	syncer := c.Space(spaceID).Environment("master").Syncer(store)
+
+	var events []SyncEventType
+	collect := func(ctx context.Context, event SyncEvent) error {
+		events = append(events, event.Type)
+		return nil
+	}
+
+	require.NoError(t, syncer.Sync(t.Context(), collect))
+	assert.Equal(t, []SyncEventType{SyncEntryUpserted, SyncEntryUpserted, SyncAssetUpserted}, events)
+	token, err := store.Token(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "delta1", token)
+	require.Len(t, store.Entries(), 2)
+	assert.Equal(t, map[string]any{"en-US": "Dog"}, store.Entry("dog").Fields["name"])
+	assert.Equal(t, "Photo", store.Asset("photo").Fields.Title["en-US"])
+
+	events = nil
+	require.NoError(t, syncer.Sync(t.Context(), collect))
+	assert.Equal(t, []SyncEventType{SyncEntryDeleted, SyncAssetDeleted}, events)
+	assert.Nil(t, store.Entry("dog"))
+	assert.NotNil(t, store.Entry("cat"))
+	assert.Empty(t, store.Assets())
+	token, _ = store.Token(t.Context())
+	assert.Equal(t, "delta2", token)
+}
+
+func TestSyncerSyncHandlerError(t *testing.T) {
+	server := newSyncServer(t)
+	defer server.Close()
+
+	c, err := New(APICDA, CMAToken, WithBaseURL(server.URL), WithEnvironment("master"))
+	require.NoError(t, err)
+
+	store := NewMemorySyncStore()
+	boom := errors.New("boom")
+	err = NewSyncer(c, spaceID, store).Sync(t.Context(), func(ctx context.Context, event SyncEvent) error {
+		if event.Type == SyncAssetUpserted {
+			return boom
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, boom)
+
+	// the first page has been applied, the second one is delivered again
+	token, _ := store.Token(t.Context())
+	assert.Equal(t, "page2", token)
+	assert.Len(t, store.Entries(), 2)
+	assert.Empty(t, store.Assets())
+}
+
+func TestFileSyncStore(t *testing.T) {
+	server := newSyncServer(t)
+	defer server.Close()
+
+	c, err := New(APICDA, CMAToken, WithBaseURL(server.URL), WithEnvironment("master"))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "sync.jsonl")
+	store, err := OpenFileSyncStore(path)
+	require.NoError(t, err)
+	require.NoError(t, NewSyncer(c, spaceID, store).Sync(t.Context(), nil))
+	require.NoError(t, store.Close())
+
+	// a crash while writing a page leaves an incomplete page
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"event":{"type":"entry.deleted","sys":{"id":"cat"}}}` + "\n" + `{"tok`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	store, err = OpenFileSyncStore(path)
+	require.NoError(t, err)
+	token, _ := store.Token(t.Context())
+	assert.Equal(t, "delta1", token)
+	assert.Len(t, store.Entries(), 2)
+	assert.NotNil(t, store.Asset("photo"))
+
+	require.NoError(t, NewSyncer(c, spaceID, store).Sync(t.Context(), nil))
+	require.NoError(t, store.Compact())
+	require.NoError(t, store.Close())
+
+	store, err = OpenFileSyncStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+	token, _ = store.Token(t.Context())
+	assert.Equal(t, "delta2", token)
+	require.Len(t, store.Entries(), 1)
+	assert.Equal(t, "cat", store.Entries()[0].Sys.ID)
+	assert.Empty(t, store.Assets())
+}
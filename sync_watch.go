@@ -0,0 +1,135 @@
+package contentful
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+type syncSubscription struct {
+	contentTypes []string
+	fn           func(ctx context.Context, event SyncEvent) error
+}
+
+// matches reports whether the event is delivered to the subscription. Asset
+// events only match subscriptions without content types.
+func (sub *syncSubscription) matches(event SyncEvent) bool {
+	if len(sub.contentTypes) == 0 {
+		return true
+	}
+	if event.Sys.ContentType == nil || event.Sys.ContentType.Sys == nil {
+		return false
+	}
+
+	return slices.Contains(sub.contentTypes, event.Sys.ContentType.Sys.ID)
+}
+
+// Subscribe calls fn for the events of entries of the given content types,
+// or for all events if none are given. An error returned by fn aborts the
+// sync and the page is delivered again. The returned func unsubscribes.
+func (s *Syncer) Subscribe(fn func(ctx context.Context, event SyncEvent) error, contentTypes ...string) func() {
+	sub := &syncSubscription{
+		contentTypes: contentTypes,
+		fn:           fn,
+	}
+
+	s.mu.Lock()
+	s.subscriptions = append(s.subscriptions, sub)
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.subscriptions = slices.DeleteFunc(s.subscriptions, func(other *syncSubscription) bool {
+			return other == sub
+		})
+	}
+}
+
+// Events returns a channel receiving the events of entries of the given
+// content types, or all events if none are given. Sync blocks until the
+// events are received. The channel is closed once ctx is done.
+func (s *Syncer) Events(ctx context.Context, buffer int, contentTypes ...string) <-chan SyncEvent {
+	events := make(chan SyncEvent, buffer)
+	done := make(chan struct{})
+
+	unsubscribe := s.Subscribe(func(syncCtx context.Context, event SyncEvent) error {
+		select {
+		case events <- event:
+			return nil
+		case <-done:
+			// the subscriber is gone
+			return nil
+		case <-syncCtx.Done():
+			return syncCtx.Err()
+		}
+	}, contentTypes...)
+
+	go func() {
+		<-ctx.Done()
+		close(done)
+		unsubscribe()
+		// wait for a running delivery before closing
+		s.running.Lock()
+		close(events)
+		s.running.Unlock()
+	}()
+
+	return events
+}
+
+// Watch calls Sync every interval until ctx is done. Failed syncs are logged
+// and retried with the next interval. The context error is returned.
+func (s *Syncer) Watch(ctx context.Context, interval time.Duration) error {
+	for {
+		if err := s.Sync(ctx, nil); err != nil && ctx.Err() == nil {
+			if logger := s.c.log(); logger != nil {
+				logger.WarnContext(ctx, "contentful sync failed",
+					slog.String("space", s.spaceID),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// publish delivers the events to the subscriptions
+func (s *Syncer) publish(ctx context.Context, events []SyncEvent) error {
+	s.mu.Lock()
+	subscriptions := slices.Clone(s.subscriptions)
+	s.mu.Unlock()
+
+	for _, event := range events {
+		for _, sub := range subscriptions {
+			if !sub.matches(event) {
+				continue
+			}
+			if err := sub.fn(ctx, event); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// contentType sets the content type of deleted entries, which the sync api
+// does not return, if the store knows the entry
+func (s *Syncer) contentType(event SyncEvent) {
+	if event.Type != SyncEntryDeleted || event.Sys.ContentType != nil {
+		return
+	}
+
+	store, ok := s.store.(interface{ Entry(id string) *Entry })
+	if !ok {
+		return
+	}
+	if entry := store.Entry(event.Sys.ID); entry != nil && entry.Sys != nil {
+		event.Sys.ContentType = entry.Sys.ContentType
+	}
+}
@@ -0,0 +1,110 @@
+package contentful
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncerWatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch query := r.URL.Query(); {
+		case query.Get("initial") == "true":
+			_, _ = w.Write([]byte(`{"items":[
+				{"sys":{"type":"Entry","id":"cat","contentType":{"sys":{"id":"animal"}}}},
+				{"sys":{"type":"Entry","id":"home","contentType":{"sys":{"id":"page"}}}},
+				{"sys":{"type":"Asset","id":"photo"}}
+			],"nextSyncUrl":"https://cdn.contentful.com/spaces/id1/sync?sync_token=delta1"}`))
+		case query.Get("sync_token") == "delta1":
+			_, _ = w.Write([]byte(`{"items":[
+				{"sys":{"type":"DeletedEntry","id":"cat"}}
+			],"nextSyncUrl":"https://cdn.contentful.com/spaces/id1/sync?sync_token=delta2"}`))
+		default:
+			_, _ = w.Write([]byte(`{"items":[],"nextSyncUrl":"https://cdn.contentful.com/spaces/id1/sync?sync_token=delta2"}`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(APICDA, CMAToken, WithBaseURL(server.URL))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	syncer := NewSyncer(c, spaceID, NewMemorySyncStore())
+	animals := syncer.Events(ctx, 0, "animal")
+
+	var mu sync.Mutex
+	var all []string
+	syncer.Subscribe(func(ctx context.Context, event SyncEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		all = append(all, string(event.Type)+" "+event.Sys.ID)
+		return nil
+	})
+	unsubscribed := 0
+	unsubscribe := syncer.Subscribe(func(ctx context.Context, event SyncEvent) error {
+		unsubscribed++
+		return nil
+	}, "page")
+	unsubscribe()
+
+	watched := make(chan error)
+	go func() {
+		watched <- syncer.Watch(ctx, time.Millisecond)
+	}()
+
+	event := <-animals
+	assert.Equal(t, SyncEntryUpserted, event.Type)
+	assert.Equal(t, "cat", event.Sys.ID)
+	event = <-animals
+	assert.Equal(t, SyncEntryDeleted, event.Type)
+	assert.Equal(t, "animal", event.Sys.ContentType.Sys.ID)
+
+	cancel()
+	require.ErrorIs(t, <-watched, context.Canceled)
+	_, open := <-animals
+	assert.False(t, open)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{
+		"entry.upserted cat",
+		"entry.upserted home",
+		"asset.upserted photo",
+		"entry.deleted cat",
+	}, all)
+	assert.Zero(t, unsubscribed)
+}
+
+func TestSyncerWatchWithoutLogger(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"sys":{"type":"Error","id":"NotFound"}}`))
+	}))
+	defer server.Close()
+
+	c, err := New(APICDA, CMAToken, WithBaseURL(server.URL), WithRetryPolicy(NoRetryPolicy()))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	syncer := NewSyncer(c, spaceID, NewMemorySyncStore())
+	require.ErrorIs(t, syncer.Watch(ctx, time.Millisecond), context.DeadlineExceeded)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Greater(t, requests, 1, "failed syncs are retried")
+}
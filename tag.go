@@ -1,10 +1,12 @@
 package contentful
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
 // TagsService servıce
@@ -16,11 +18,22 @@ type Tag struct {
 	Name string `json:"name,omitempty"`
 }
 
+// GetVersion returns entity version
+func (tag *Tag) GetVersion() int {
+	version := 1
+	if tag.Sys != nil {
+		version = tag.Sys.Version
+	}
+
+	return version
+}
+
 // List returns tags collection
 func (service *TagsService) List(ctx context.Context, spaceID string) *Collection[Tag] {
 	path := fmt.Sprintf("/spaces/%s%s/tags", spaceID, getEnvPath(service.c))
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Tags", "List", spaceID, "")
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return &Collection[Tag]{}
@@ -33,15 +46,16 @@ func (service *TagsService) List(ctx context.Context, spaceID string) *Collectio
 	return col
 }
 
-// Get returns a single entry
+// Get returns a single tag
 func (service *TagsService) Get(ctx context.Context, spaceID, tagID string, locale ...string) (*Tag, error) {
-	path := fmt.Sprintf("/spaces/%s%s/entries/%s", spaceID, getEnvPath(service.c), tagID)
+	path := fmt.Sprintf("/spaces/%s%s/tags/%s", spaceID, getEnvPath(service.c), tagID)
 	query := url.Values{}
 	if len(locale) > 0 {
 		query["locale"] = locale
 	}
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Tags", "Get", spaceID, tagID)
 	req, err := service.c.newRequest(ctx, method, path, query, nil, nil)
 	if err != nil {
 		return &Tag{}, err
@@ -54,3 +68,25 @@ func (service *TagsService) Get(ctx context.Context, spaceID, tagID string, loca
 
 	return tag, err
 }
+
+// Upsert updates or creates a tag with its id. The visibility of sys is
+// only used when the tag is created.
+func (service *TagsService) Upsert(ctx context.Context, spaceID string, tag *Tag) error {
+	bytesArray, err := Marshal(tag)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/spaces/%s%s/tags/%s", spaceID, getEnvPath(service.c), tag.Sys.ID)
+	method := http.MethodPut
+
+	ctx = service.c.operation(ctx, "Tags", "Upsert", spaceID, tag.Sys.ID)
+	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("X-Contentful-Version", strconv.Itoa(tag.GetVersion()))
+
+	return service.c.do(req, tag)
+}
@@ -0,0 +1,29 @@
+package contentful
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/spaces/"+spaceID+"/tags/cute", r.URL.Path)
+		checkHeaders(t, r)
+
+		_, _ = w.Write([]byte(`{"sys": {"id": "cute", "type": "Tag", "version": 1, "visibility": "public"}, "name": "Cute"}`))
+	}))
+	defer server.Close()
+
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	tag, err := cma.Tags.Get(t.Context(), spaceID, "cute")
+	require.NoError(t, err)
+	assert.Equal(t, "Cute", tag.Name)
+	assert.Equal(t, "public", tag.Sys.Visibility)
+}
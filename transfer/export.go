@@ -0,0 +1,395 @@
+// Package transfer exports spaces to and imports them from the JSON layout
+// of the official contentful-export and contentful-import tools.
+//
+//	f, _ := os.Create("export.json")
+//	defer f.Close()
+//	err := transfer.Export(ctx, cma.Space("space-id"), "master", f, &transfer.ExportOptions{
+//		ContentTypes: []string{"cat", "dog"},
+//		AssetDir:     "export",
+//	})
+package transfer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/foomo/contentful"
+)
+
+// keys of the export file in the order they are written
+const (
+	KeyContentTypes     = "contentTypes"
+	KeyTags             = "tags"
+	KeyEditorInterfaces = "editorInterfaces"
+	KeyEntries          = "entries"
+	KeyAssets           = "assets"
+	KeyLocales          = "locales"
+	KeyWebhooks         = "webhooks"
+	KeyRoles            = "roles"
+)
+
+// exportPageSize is the number of items requested per page
+const exportPageSize = 1000
+
+// ExportOptions selects what is exported
+type ExportOptions struct {
+	// ContentTypes limits content types, editor interfaces and entries to
+	// the given content type ids
+	ContentTypes []string
+	// EntryQuery filters the exported entries
+	EntryQuery *contentful.Query
+	// AssetQuery filters the exported assets
+	AssetQuery *contentful.Query
+	// IncludeDrafts exports entries and assets which have never been published
+	IncludeDrafts bool
+	// IncludeArchived exports archived entries and assets
+	IncludeArchived bool
+
+	// SkipContentModel skips content types and editor interfaces
+	SkipContentModel bool
+	// SkipEditorInterfaces skips editor interfaces
+	SkipEditorInterfaces bool
+	// SkipContent skips entries and assets
+	SkipContent bool
+	// SkipTags skips tags
+	SkipTags bool
+	// SkipWebhooks skips webhooks
+	SkipWebhooks bool
+	// SkipRoles skips roles
+	SkipRoles bool
+
+	// AssetDir enables the download of asset files. Files are stored by the
+	// host and path of their url, as the export tool does.
+	AssetDir string
+	// HTTPClient downloads asset files, defaults to http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// Export writes the environment of the space to w in the layout of the
+// contentful-export tool. Items are written while they are fetched page by
+// page, so the size of the space does not matter. Webhooks and roles are
+// read from the space.
+func Export(ctx context.Context, space *contentful.SpaceClient, environmentID string, w io.Writer, opts *ExportOptions) error {
+	if opts == nil {
+		opts = &ExportOptions{}
+	}
+
+	env := space.Environment(environmentID)
+	e := &exporter{opts: opts, out: newStream(w)}
+
+	e.out.begin()
+	if !opts.SkipContentModel {
+		e.section(KeyContentTypes, filter(ctx, env.ContentTypes.List(ctx), opts.contentType))
+	}
+	if !opts.SkipTags {
+		e.section(KeyTags, all(ctx, env.Tags.List(ctx)))
+	}
+	if !opts.SkipContentModel && !opts.SkipEditorInterfaces {
+		e.section(KeyEditorInterfaces, filter(ctx, env.EditorInterfaces.List(ctx), opts.editorInterface))
+	}
+	if !opts.SkipContent {
+		e.section(KeyEntries, filter(ctx, opts.entries(env.Entries.List(ctx)), opts.entry))
+		e.section(KeyAssets, e.download(ctx, filter(ctx, opts.assets(env.Assets.List(ctx)), opts.asset)))
+	}
+	e.section(KeyLocales, all(ctx, env.Locales.List(ctx)))
+	if !opts.SkipWebhooks {
+		e.section(KeyWebhooks, all(ctx, space.Webhooks.List(ctx)))
+	}
+	if !opts.SkipRoles {
+		e.section(KeyRoles, all(ctx, space.Roles.List(ctx)))
+	}
+
+	return e.out.end()
+}
+
+type exporter struct {
+	opts *ExportOptions
+	out  *stream
+}
+
+// section writes the items of the sequence as an array
+func (e *exporter) section(key string, items iter.Seq2[any, error]) {
+	if e.out.err != nil {
+		return
+	}
+
+	e.out.key(key)
+	for item, err := range items {
+		if err != nil {
+			e.out.fail(fmt.Errorf("exporting %s: %w", key, err))
+			return
+		}
+		e.out.item(item)
+		if e.out.err != nil {
+			return
+		}
+	}
+	e.out.close()
+}
+
+// download stores the files of the assets in AssetDir while they are passed on
+func (e *exporter) download(ctx context.Context, assets iter.Seq2[any, error]) iter.Seq2[any, error] {
+	if e.opts.AssetDir == "" {
+		return assets
+	}
+
+	return func(yield func(any, error) bool) {
+		for item, err := range assets {
+			if err == nil {
+				err = e.downloadFiles(ctx, item.(*contentful.Asset))
+			}
+			if !yield(item, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (e *exporter) downloadFiles(ctx context.Context, asset *contentful.Asset) error {
+	if asset.Fields == nil {
+		return nil
+	}
+
+	for _, locale := range sortedKeys(asset.Fields.File) {
+		file := asset.Fields.File[locale]
+		if file == nil || file.URL == "" {
+			continue
+		}
+		if err := e.downloadFile(ctx, file.URL); err != nil {
+			return fmt.Errorf("downloading file of asset %q in %s: %w", asset.Sys.ID, locale, err)
+		}
+	}
+
+	return nil
+}
+
+func (e *exporter) downloadFile(ctx context.Context, rawURL string) error {
+	u, path, err := fileURL(e.opts.AssetDir, rawURL)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	client := e.opts.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, res.Body); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// fileURL returns the absolute url of an asset file and the path the file
+// is stored at in dir. Urls leading outside of dir are rejected.
+func fileURL(dir, rawURL string) (*url.URL, string, error) {
+	if strings.HasPrefix(rawURL, "//") {
+		rawURL = "https:" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	path := filepath.FromSlash(strings.TrimPrefix(u.Path, "/"))
+	if !filepath.IsLocal(u.Host) || !filepath.IsLocal(path) {
+		return nil, "", fmt.Errorf("asset file url %q leads outside of the asset dir", rawURL)
+	}
+
+	return u, filepath.Join(dir, u.Host, path), nil
+}
+
+func (opts *ExportOptions) contentType(ct *contentful.ContentType) bool {
+	return opts.selected(ct.Sys.ID)
+}
+
+func (opts *ExportOptions) editorInterface(ei *contentful.EditorInterface) bool {
+	return opts.selected(ei.ContentTypeID())
+}
+
+func (opts *ExportOptions) entry(entry *contentful.Entry) bool {
+	return opts.state(entry.Sys)
+}
+
+func (opts *ExportOptions) asset(asset *contentful.Asset) bool {
+	return opts.state(asset.Sys)
+}
+
+func (opts *ExportOptions) selected(contentTypeID string) bool {
+	return len(opts.ContentTypes) == 0 || slices.Contains(opts.ContentTypes, contentTypeID)
+}
+
+// state reports whether an entity is exported by its publishing state
+func (opts *ExportOptions) state(sys *contentful.Sys) bool {
+	switch {
+	case sys.ArchivedVersion > 0:
+		return opts.IncludeArchived
+	case sys.PublishedVersion == 0:
+		return opts.IncludeDrafts
+	default:
+		return true
+	}
+}
+
+// entries applies the entry query and the content types to the collection
+func (opts *ExportOptions) entries(col *contentful.Collection[contentful.Entry]) *contentful.Collection[contentful.Entry] {
+	query(&col.Query, opts.EntryQuery)
+	if len(opts.ContentTypes) > 0 {
+		col.Query.In("sys.contentType.sys.id", opts.ContentTypes)
+	}
+
+	return col
+}
+
+// assets applies the asset query to the collection
+func (opts *ExportOptions) assets(col *contentful.Collection[contentful.Asset]) *contentful.Collection[contentful.Asset] {
+	query(&col.Query, opts.AssetQuery)
+
+	return col
+}
+
+// query replaces the query of a collection with a copy of q, using the
+// export page size unless q sets a limit
+func query(dst, q *contentful.Query) {
+	if q != nil {
+		*dst = *q.Clone()
+		if dst.Values().Get("limit") != "" {
+			return
+		}
+	}
+	dst.Limit(exportPageSize)
+}
+
+// all iterates the items of all pages of the collection
+func all[T any](ctx context.Context, col *contentful.Collection[T]) iter.Seq2[any, error] {
+	return filter(ctx, col, nil)
+}
+
+// filter iterates the items of all pages of the collection accepted by keep
+func filter[T any](ctx context.Context, col *contentful.Collection[T], keep func(*T) bool) iter.Seq2[any, error] {
+	return func(yield func(any, error) bool) {
+		for item, err := range col.All(ctx) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if keep != nil && !keep(&item) {
+				continue
+			}
+			if !yield(&item, nil) {
+				return
+			}
+		}
+	}
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// stream writes an indented json object of arrays item by item
+type stream struct {
+	w     *bufio.Writer
+	err   error
+	keys  int
+	items int
+}
+
+func newStream(w io.Writer) *stream {
+	return &stream{w: bufio.NewWriter(w)}
+}
+
+func (s *stream) fail(err error) {
+	if s.err == nil {
+		s.err = err
+	}
+}
+
+func (s *stream) write(str string) {
+	if s.err != nil {
+		return
+	}
+	if _, err := s.w.WriteString(str); err != nil {
+		s.fail(err)
+	}
+}
+
+func (s *stream) begin() {
+	s.write("{")
+}
+
+func (s *stream) key(key string) {
+	if s.keys > 0 {
+		s.write(",")
+	}
+	s.keys++
+	s.items = 0
+	s.write(fmt.Sprintf("\n  %q: [", key))
+}
+
+func (s *stream) item(v any) {
+	data, err := json.MarshalIndent(v, "    ", "  ")
+	if err != nil {
+		s.fail(err)
+		return
+	}
+	if s.items > 0 {
+		s.write(",")
+	}
+	s.items++
+	s.write("\n    ")
+	s.write(string(data))
+}
+
+func (s *stream) close() {
+	if s.items > 0 {
+		s.write("\n  ")
+	}
+	s.write("]")
+}
+
+func (s *stream) end() error {
+	s.write("\n}\n")
+	if s.err != nil {
+		return s.err
+	}
+
+	return s.w.Flush()
+}
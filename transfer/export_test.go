@@ -0,0 +1,217 @@
+package transfer
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/foomo/contentful"
+	"github.com/foomo/contentful/internal/cmafake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exportSpace = `{
+	"content_types": [
+		{"sys": {"id": "cat", "version": 2, "publishedVersion": 1}, "name": "Cat", "displayField": "name", "fields": [
+			{"id": "name", "name": "Name", "type": "Symbol", "validations": [{"size": {"max": 20}}]},
+			{"id": "photo", "name": "Photo", "type": "Link", "linkType": "Asset", "validations": [{"assetImageDimensions": {"width": {"min": 10, "max": 1000}}}]}
+		]},
+		{"sys": {"id": "dog", "version": 2, "publishedVersion": 1}, "name": "Dog", "fields": []}
+	],
+	"editor_interfaces": [
+		{"sys": {"id": "default", "version": 3, "contentType": {"sys": {"id": "cat", "type": "Link", "linkType": "ContentType"}}}, "controls": [{"fieldId": "name", "widgetId": "singleLine", "widgetNamespace": "builtin"}]},
+		{"sys": {"id": "default", "version": 1, "contentType": {"sys": {"id": "dog", "type": "Link", "linkType": "ContentType"}}}, "controls": []}
+	],
+	"entries": [
+		{"sys": {"id": "nyancat", "version": 3, "publishedVersion": 2, "contentType": {"sys": {"id": "cat"}}}, "fields": {"name": {"en-US": "Nyan Cat"}, "photo": {"en-US": {"sys": {"type": "Link", "linkType": "Asset", "id": "nyancat"}}}}},
+		{"sys": {"id": "draftcat", "version": 1, "contentType": {"sys": {"id": "cat"}}}, "fields": {"name": {"en-US": "Draft Cat"}}},
+		{"sys": {"id": "oldcat", "version": 4, "publishedVersion": 2, "archivedVersion": 3, "contentType": {"sys": {"id": "cat"}}}, "fields": {"name": {"en-US": "Old Cat"}}},
+		{"sys": {"id": "rex", "version": 2, "publishedVersion": 1, "contentType": {"sys": {"id": "dog"}}}, "fields": {}}
+	],
+	"assets": [
+		{"sys": {"id": "nyancat", "version": 2, "publishedVersion": 1}, "fields": {"title": {"en-US": "Nyan Cat"}, "file": {"en-US": {"fileName": "nyancat.png", "contentType": "image/png", "url": "//{{files}}/space/nyancat/token/nyancat.png"}}}}
+	],
+	"locales": [
+		{"sys": {"id": "en"}, "name": "English", "code": "en-US", "default": true, "contentDeliveryApi": true, "contentManagementApi": true}
+	],
+	"tags": [
+		{"sys": {"id": "cute", "type": "Tag", "visibility": "private"}, "name": "Cute"}
+	],
+	"webhook_definitions": [
+		{"sys": {"id": "hook"}, "name": "Hook", "url": "https://example.com", "topics": ["*.*"]}
+	],
+	"roles": [
+		{"sys": {"id": "editor", "version": 1}, "name": "Editor", "policies": [{"effect": "allow", "actions": "all"}], "permissions": {"ContentModel": ["read"]}}
+	]
+}`
+
+func TestExport(t *testing.T) {
+	files := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("png:" + r.URL.Path))
+	}))
+	defer files.Close()
+
+	host := files.Listener.Addr().String()
+	_, space := cmafake.New(t, strings.ReplaceAll(exportSpace, "{{files}}", host))
+
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	require.NoError(t, Export(t.Context(), space, "master", &buf, &ExportOptions{
+		AssetDir:   dir,
+		HTTPClient: files.Client(),
+	}))
+
+	export := decodeExport(t, buf.Bytes())
+	assert.Equal(t, []string{KeyContentTypes, KeyTags, KeyEditorInterfaces, KeyEntries, KeyAssets, KeyLocales, KeyWebhooks, KeyRoles}, exportKeys(t, buf.Bytes()))
+	assert.Equal(t, []string{"cat", "dog"}, ids(export[KeyContentTypes]))
+	assert.Equal(t, []string{"nyancat", "rex"}, ids(export[KeyEntries]), "drafts and archived entries are skipped")
+	assert.Equal(t, []string{"nyancat"}, ids(export[KeyAssets]))
+	assert.Equal(t, []string{"cute"}, ids(export[KeyTags]))
+	assert.Equal(t, []string{"en"}, ids(export[KeyLocales]))
+	assert.Equal(t, []string{"hook"}, ids(export[KeyWebhooks]))
+	assert.Equal(t, []string{"editor"}, ids(export[KeyRoles]))
+	assert.Len(t, export[KeyEditorInterfaces], 2)
+
+	// validations keep their shape
+	fields := export[KeyContentTypes][0]["fields"].([]interface{})
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"assetImageDimensions": map[string]interface{}{"width": map[string]interface{}{"min": float64(10), "max": float64(1000)}}},
+	}, fields[1].(map[string]interface{})["validations"])
+
+	data, err := os.ReadFile(filepath.Join(dir, host, "space", "nyancat", "token", "nyancat.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "png:/space/nyancat/token/nyancat.png", string(data))
+}
+
+func TestExportFilter(t *testing.T) {
+	f, space := cmafake.New(t, exportSpace)
+
+	entryQuery := contentful.NewQuery().Order("sys.id", false)
+	var buf bytes.Buffer
+	require.NoError(t, Export(t.Context(), space, "master", &buf, &ExportOptions{
+		ContentTypes:    []string{"cat"},
+		EntryQuery:      entryQuery,
+		IncludeDrafts:   true,
+		IncludeArchived: true,
+		SkipTags:        true,
+		SkipWebhooks:    true,
+		SkipRoles:       true,
+	}))
+
+	export := decodeExport(t, buf.Bytes())
+	assert.Equal(t, []string{KeyContentTypes, KeyEditorInterfaces, KeyEntries, KeyAssets, KeyLocales}, exportKeys(t, buf.Bytes()))
+	assert.Equal(t, []string{"cat"}, ids(export[KeyContentTypes]))
+	assert.Equal(t, []string{"nyancat", "draftcat", "oldcat"}, ids(export[KeyEntries]))
+	assert.Len(t, export[KeyEditorInterfaces], 1)
+
+	assert.Contains(t, f.Requests, "GET /environments/master/entries?limit=1000&order=sys.id&sys.contentType.sys.id%5Bin%5D=cat")
+	assert.Contains(t, f.Requests, "GET /environments/master/assets?limit=1000")
+	assert.Equal(t, "order=sys.id", entryQuery.String(), "the entry query is not changed")
+}
+
+func TestExportLayout(t *testing.T) {
+	_, space := cmafake.New(t, `{"locales": [{"sys": {"id": "en"}, "code": "en-US"}]}`)
+
+	var buf bytes.Buffer
+	require.NoError(t, Export(t.Context(), space, "master", &buf, &ExportOptions{
+		SkipContentModel: true,
+		SkipRoles:        true,
+		SkipWebhooks:     true,
+	}))
+
+	assert.Equal(t, `{
+  "tags": [],
+  "entries": [],
+  "assets": [],
+  "locales": [
+    {
+      "sys": {
+        "id": "en"
+      },
+      "code": "en-US",
+      "contentDeliveryApi": false,
+      "contentManagementApi": false
+    }
+  ]
+}
+`, buf.String())
+}
+
+func decodeExport(t *testing.T, data []byte) map[string][]map[string]interface{} {
+	t.Helper()
+	var export map[string][]map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &export))
+
+	return export
+}
+
+// exportKeys returns the keys of the export in the order they are written
+func exportKeys(t *testing.T, data []byte) []string {
+	t.Helper()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	_, err := dec.Token()
+	require.NoError(t, err)
+
+	var keys []string
+	for dec.More() {
+		key, err := dec.Token()
+		require.NoError(t, err)
+		keys = append(keys, key.(string))
+		var value json.RawMessage
+		require.NoError(t, dec.Decode(&value))
+	}
+
+	return keys
+}
+
+func ids(items []map[string]interface{}) []string {
+	var ids []string
+	for _, item := range items {
+		ids = append(ids, item["sys"].(map[string]interface{})["id"].(string))
+	}
+
+	return ids
+}
+
+func TestFileURL(t *testing.T) {
+	u, path, err := fileURL("export", "//images.ctfassets.net/space/cat/token/cat.png")
+	require.NoError(t, err)
+	assert.Equal(t, "https://images.ctfassets.net/space/cat/token/cat.png", u.String())
+	assert.Equal(t, filepath.Join("export", "images.ctfassets.net", "space", "cat", "token", "cat.png"), path)
+
+	for _, rawURL := range []string{
+		"//images.ctfassets.net/../../../../etc/passwd",
+		"//../../.ssh/id_rsa",
+		"//images.ctfassets.net/space/../../../cat.png",
+		"//..",
+	} {
+		_, _, err := fileURL("export", rawURL)
+		require.Error(t, err, rawURL)
+	}
+}
+
+func TestExportAssetOutsideDir(t *testing.T) {
+	files := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("evil"))
+	}))
+	defer files.Close()
+
+	_, space := cmafake.New(t, strings.ReplaceAll(exportSpace, "{{files}}/space", files.Listener.Addr().String()+"/../../.."))
+
+	root := t.TempDir()
+	var buf bytes.Buffer
+	err := Export(t.Context(), space, "master", &buf, &ExportOptions{
+		AssetDir:   filepath.Join(root, "export", "assets"),
+		HTTPClient: files.Client(),
+	})
+	require.ErrorContains(t, err, "leads outside of the asset dir")
+
+	_, err = os.Stat(filepath.Join(root, "nyancat"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
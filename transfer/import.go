@@ -0,0 +1,767 @@
+package transfer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/foomo/contentful"
+)
+
+const (
+	// lookupBatchSize is the number of ids looked up with one request
+	lookupBatchSize = 100
+	// defaultPollInterval is the interval the processing of assets is polled with
+	defaultPollInterval = time.Second
+	// defaultProcessTimeout limits the processing of an asset
+	defaultProcessTimeout = time.Minute
+)
+
+// ImportOptions selects what is imported and how existing entities are handled
+type ImportOptions struct {
+	// SkipExisting leaves entities which exist in the environment unchanged,
+	// otherwise they are updated with their current version
+	SkipExisting bool
+
+	// SkipLocales skips locales
+	SkipLocales bool
+	// SkipContentModel skips content types and editor interfaces
+	SkipContentModel bool
+	// SkipEditorInterfaces skips editor interfaces
+	SkipEditorInterfaces bool
+	// SkipContent skips entries and assets
+	SkipContent bool
+	// SkipTags skips tags
+	SkipTags bool
+	// SkipWebhooks skips webhooks
+	SkipWebhooks bool
+	// SkipRoles skips roles
+	SkipRoles bool
+
+	// AssetDir uploads the asset files downloaded by Export from the
+	// directory, otherwise Contentful fetches the files from their url
+	AssetDir string
+	// PollInterval is the interval the processing of assets is polled
+	// with, defaults to a second
+	PollInterval time.Duration
+	// ProcessTimeout limits the processing of an asset, defaults to a minute
+	ProcessTimeout time.Duration
+}
+
+// Action is what an import did with an entity
+type Action string
+
+const (
+	ActionCreated Action = "created"
+	ActionUpdated Action = "updated"
+	ActionSkipped Action = "skipped"
+	ActionFailed  Action = "failed"
+)
+
+// Result is the outcome of the import of an entity
+type Result struct {
+	// Type is the key of the entity in the export, e.g. KeyEntries
+	Type string
+	// ID is the id of the entity, the code for locales and the content
+	// type id for editor interfaces
+	ID     string
+	Action Action
+	Err    error
+}
+
+// Report lists the results of an import
+type Report struct {
+	Results []*Result
+}
+
+// Failed returns the results of the entities which failed to import
+func (r *Report) Failed() []*Result {
+	var failed []*Result
+	for _, result := range r.Results {
+		if result.Action == ActionFailed {
+			failed = append(failed, result)
+		}
+	}
+
+	return failed
+}
+
+// Err joins the errors of the entities which failed to import
+func (r *Report) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("%s %q: %w", result.Type, result.ID, result.Err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// String counts the actions by type
+func (r *Report) String() string {
+	counts := map[string]map[Action]int{}
+	for _, result := range r.Results {
+		if counts[result.Type] == nil {
+			counts[result.Type] = map[Action]int{}
+		}
+		counts[result.Type][result.Action]++
+	}
+
+	var b strings.Builder
+	for _, key := range []string{KeyLocales, KeyTags, KeyContentTypes, KeyEditorInterfaces, KeyAssets, KeyEntries, KeyWebhooks, KeyRoles} {
+		if c, ok := counts[key]; ok {
+			fmt.Fprintf(&b, "%s: %d created, %d updated, %d skipped, %d failed\n",
+				key, c[ActionCreated], c[ActionUpdated], c[ActionSkipped], c[ActionFailed])
+		}
+	}
+
+	return b.String()
+}
+
+func (r *Report) add(typ, id string, action Action, err error) {
+	if err != nil {
+		action = ActionFailed
+	}
+	r.Results = append(r.Results, &Result{Type: typ, ID: id, Action: action, Err: err})
+}
+
+// Import recreates the snapshot in the environment of the space, keeping
+// the ids of the entities. Locales, tags and content types are imported
+// first, assets are processed before entries and entries are created in an
+// order which puts linked entries first, before they are published.
+//
+// Entities which exist are updated with their current version, unchanged
+// ones are skipped, so an import can be resumed by running it again. The
+// failure of an entity does not stop the import, the report lists the
+// result of every entity and the returned error joins the failures.
+// Archived entities are imported as drafts.
+func Import(ctx context.Context, space *contentful.SpaceClient, environmentID string, snapshot *Snapshot, opts *ImportOptions) (*Report, error) {
+	if opts == nil {
+		opts = &ImportOptions{}
+	}
+
+	i := &importer{
+		space:    space,
+		env:      space.Environment(environmentID),
+		snapshot: snapshot,
+		opts:     opts,
+		report:   &Report{},
+		existing: map[string]bool{},
+	}
+
+	steps := []struct {
+		skip bool
+		run  func(ctx context.Context) error
+	}{
+		{opts.SkipLocales, i.locales},
+		{opts.SkipTags, i.tags},
+		{opts.SkipContentModel, i.contentTypes},
+		{opts.SkipContentModel || opts.SkipEditorInterfaces, i.editorInterfaces},
+		{opts.SkipContent, i.assets},
+		{opts.SkipContent, i.entries},
+		{opts.SkipWebhooks, i.webhooks},
+		{opts.SkipRoles, i.roles},
+	}
+	for _, step := range steps {
+		if step.skip {
+			continue
+		}
+		if err := step.run(ctx); err != nil {
+			return i.report, err
+		}
+	}
+
+	return i.report, i.report.Err()
+}
+
+type importer struct {
+	space    *contentful.SpaceClient
+	env      *contentful.EnvironmentClient
+	snapshot *Snapshot
+	opts     *ImportOptions
+	report   *Report
+	// existing holds the ids of the content types which existed before
+	existing map[string]bool
+}
+
+func (i *importer) locales(ctx context.Context) error {
+	existing, err := byID(ctx, i.env.Locales.List(ctx), func(l *contentful.Locale) string { return l.Code })
+	if err != nil {
+		return fmt.Errorf("listing locales: %w", err)
+	}
+
+	locales := map[string]*contentful.Locale{}
+	codes := make([]string, 0, len(i.snapshot.Locales))
+	for _, locale := range i.snapshot.Locales {
+		locales[locale.Code] = locale
+		codes = append(codes, locale.Code)
+	}
+
+	// fallback locales have to exist first
+	for _, code := range dependencyOrder(codes, func(code string) []string {
+		return []string{locales[code].FallbackCode}
+	}) {
+		locale := locales[code]
+		current := existing[code]
+		switch {
+		case current != nil && (i.opts.SkipExisting || sameLocale(current, locale)):
+			i.report.add(KeyLocales, code, ActionSkipped, nil)
+		case current != nil:
+			current.Name = locale.Name
+			current.FallbackCode = locale.FallbackCode
+			current.Optional = locale.Optional
+			current.CDA = locale.CDA
+			current.CMA = locale.CMA
+			i.report.add(KeyLocales, code, ActionUpdated, i.env.Locales.Upsert(ctx, current))
+		default:
+			next := &contentful.Locale{
+				Name:         locale.Name,
+				Code:         locale.Code,
+				FallbackCode: locale.FallbackCode,
+				Optional:     locale.Optional,
+				CDA:          locale.CDA,
+				CMA:          locale.CMA,
+			}
+			i.report.add(KeyLocales, code, ActionCreated, i.env.Locales.Upsert(ctx, next))
+		}
+	}
+
+	return nil
+}
+
+func (i *importer) tags(ctx context.Context) error {
+	existing, err := byID(ctx, i.env.Tags.List(ctx), func(t *contentful.Tag) string { return t.Sys.ID })
+	if err != nil {
+		return fmt.Errorf("listing tags: %w", err)
+	}
+
+	for _, tag := range i.snapshot.Tags {
+		id := tag.Sys.ID
+		current := existing[id]
+		if current != nil && (i.opts.SkipExisting || current.Name == tag.Name) {
+			i.report.add(KeyTags, id, ActionSkipped, nil)
+			continue
+		}
+
+		next := &contentful.Tag{Sys: &contentful.Sys{ID: id, Visibility: tag.Sys.Visibility}, Name: tag.Name}
+		action := ActionCreated
+		if current != nil {
+			next.Sys.Version = current.Sys.Version
+			action = ActionUpdated
+		}
+		i.report.add(KeyTags, id, action, i.env.Tags.Upsert(ctx, next))
+	}
+
+	return nil
+}
+
+func (i *importer) contentTypes(ctx context.Context) error {
+	existing, err := byID(ctx, i.env.ContentTypes.List(ctx), func(ct *contentful.ContentType) string { return ct.Sys.ID })
+	if err != nil {
+		return fmt.Errorf("listing content types: %w", err)
+	}
+
+	for _, ct := range i.snapshot.ContentTypes {
+		id := ct.Sys.ID
+		current := existing[id]
+		i.existing[id] = current != nil
+		if current != nil && (i.opts.SkipExisting || sameContentType(current, ct) && active(current.Sys)) {
+			i.report.add(KeyContentTypes, id, ActionSkipped, nil)
+			continue
+		}
+
+		next := &contentful.ContentType{
+			Sys:          &contentful.Sys{ID: id},
+			Name:         ct.Name,
+			Description:  ct.Description,
+			DisplayField: ct.DisplayField,
+			Fields:       ct.Fields,
+		}
+		action := ActionCreated
+		if current != nil {
+			next.Sys.Version = current.Sys.Version
+			action = ActionUpdated
+		}
+		err := i.env.ContentTypes.Upsert(ctx, next)
+		if err == nil {
+			err = i.env.ContentTypes.Activate(ctx, next)
+		}
+		i.report.add(KeyContentTypes, id, action, err)
+	}
+
+	return nil
+}
+
+func (i *importer) editorInterfaces(ctx context.Context) error {
+	for _, ei := range i.snapshot.EditorInterfaces {
+		id := ei.ContentTypeID()
+		if i.opts.SkipExisting && i.existing[id] {
+			i.report.add(KeyEditorInterfaces, id, ActionSkipped, nil)
+			continue
+		}
+
+		// editor interfaces are created with their content type
+		current, err := i.env.EditorInterfaces.Get(ctx, id)
+		if err != nil {
+			i.report.add(KeyEditorInterfaces, id, ActionFailed, err)
+			continue
+		}
+		next := *ei
+		next.Sys = current.Sys
+		if equalWithoutSys(current, &next) {
+			i.report.add(KeyEditorInterfaces, id, ActionSkipped, nil)
+			continue
+		}
+		i.report.add(KeyEditorInterfaces, id, ActionUpdated, i.env.EditorInterfaces.Update(ctx, &next))
+	}
+
+	return nil
+}
+
+func (i *importer) assets(ctx context.Context) error {
+	ids := make([]string, 0, len(i.snapshot.Assets))
+	for _, asset := range i.snapshot.Assets {
+		ids = append(ids, asset.Sys.ID)
+	}
+	existing, err := lookup(ctx, i.env.Assets.List, ids, func(a *contentful.Asset) string { return a.Sys.ID })
+	if err != nil {
+		return fmt.Errorf("looking up assets: %w", err)
+	}
+
+	for _, asset := range i.snapshot.Assets {
+		id := asset.Sys.ID
+		current := existing[id]
+		switch {
+		case current != nil && (i.opts.SkipExisting || sameAsset(current, asset)):
+			i.report.add(KeyAssets, id, ActionSkipped, nil)
+		case current != nil:
+			i.report.add(KeyAssets, id, ActionUpdated, i.asset(ctx, asset, current.Sys.Version))
+		default:
+			i.report.add(KeyAssets, id, ActionCreated, i.asset(ctx, asset, 0))
+		}
+	}
+
+	return nil
+}
+
+// asset uploads, processes and publishes the asset
+func (i *importer) asset(ctx context.Context, asset *contentful.Asset, version int) error {
+	next := &contentful.Asset{
+		Metadata: asset.Metadata,
+		Sys:      &contentful.Sys{ID: asset.Sys.ID, Version: version},
+		Fields:   &contentful.FileFields{File: map[string]*contentful.File{}},
+	}
+	if asset.Fields != nil {
+		next.Fields.Title = asset.Fields.Title
+		next.Fields.Description = asset.Fields.Description
+		for _, locale := range sortedKeys(asset.Fields.File) {
+			file, err := i.file(ctx, asset.Fields.File[locale])
+			if err != nil {
+				return fmt.Errorf("uploading file in %s: %w", locale, err)
+			}
+			next.Fields.File[locale] = file
+		}
+	}
+
+	if err := i.env.Assets.Upsert(ctx, next); err != nil {
+		return err
+	}
+	if len(next.Fields.File) > 0 {
+		if err := i.env.Assets.Process(ctx, next); err != nil {
+			return fmt.Errorf("processing: %w", err)
+		}
+		processed, err := i.processed(ctx, next.Sys.ID)
+		if err != nil {
+			return err
+		}
+		next = processed
+	}
+	if published(asset.Sys) {
+		return i.env.Assets.Publish(ctx, next)
+	}
+
+	return nil
+}
+
+// file returns the file to be processed for an exported file
+func (i *importer) file(ctx context.Context, file *contentful.File) (*contentful.File, error) {
+	next := &contentful.File{Name: file.Name, ContentType: file.ContentType}
+	switch {
+	case file.URL == "":
+		// the file has never been processed
+		next.UploadURL = file.UploadURL
+		next.UploadFrom = file.UploadFrom
+	case i.opts.AssetDir != "":
+		// the path is contained in AssetDir, a snapshot cannot upload other local files
+		_, path, err := fileURL(i.opts.AssetDir, file.URL)
+		if err != nil {
+			return nil, err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		upload, err := i.env.Upload.Uploads(ctx, f)
+		if err != nil {
+			return nil, err
+		}
+		next.UploadFrom = &contentful.Upload{Sys: contentful.Sys{ID: upload.Sys.ID, Type: "Link", LinkType: "Upload"}}
+	default:
+		u, _, err := fileURL("", file.URL)
+		if err != nil {
+			return nil, err
+		}
+		next.UploadURL = u.String()
+	}
+
+	return next, nil
+}
+
+// processed polls the asset until all of its files have been processed
+func (i *importer) processed(ctx context.Context, id string) (*contentful.Asset, error) {
+	timeout := i.opts.ProcessTimeout
+	if timeout == 0 {
+		timeout = defaultProcessTimeout
+	}
+	interval := i.opts.PollInterval
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		asset, err := i.env.Assets.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if processedFiles(asset) {
+			return asset, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for processing: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (i *importer) entries(ctx context.Context) error {
+	entries := map[string]*contentful.Entry{}
+	ids := make([]string, 0, len(i.snapshot.Entries))
+	for _, entry := range i.snapshot.Entries {
+		entries[entry.Sys.ID] = entry
+		ids = append(ids, entry.Sys.ID)
+	}
+	existing, err := lookup(ctx, i.env.Entries.List, ids, func(e *contentful.Entry) string { return e.Sys.ID })
+	if err != nil {
+		return fmt.Errorf("looking up entries: %w", err)
+	}
+
+	type pending struct {
+		entry  *contentful.Entry
+		action Action
+	}
+
+	// entries are published after all of them have been created, linked
+	// entries first
+	var publish []pending
+	for _, id := range dependencyOrder(ids, func(id string) []string {
+		return links(entries[id].Fields)
+	}) {
+		entry := entries[id]
+		current := existing[id]
+		if current != nil && (i.opts.SkipExisting || sameEntry(current, entry)) {
+			i.report.add(KeyEntries, id, ActionSkipped, nil)
+			continue
+		}
+
+		next := &contentful.Entry{
+			Metadata: entry.Metadata,
+			Sys:      &contentful.Sys{ID: id, ContentType: &contentful.ContentType{Sys: &contentful.Sys{ID: contentTypeOf(entry)}}},
+			Fields:   entry.Fields,
+		}
+		action := ActionCreated
+		if current != nil {
+			next.Sys.Version = current.Sys.Version
+			action = ActionUpdated
+		}
+		if err := i.env.Entries.Upsert(ctx, next); err != nil || !published(entry.Sys) {
+			i.report.add(KeyEntries, id, action, err)
+			continue
+		}
+		publish = append(publish, pending{entry: next, action: action})
+	}
+
+	for _, p := range publish {
+		i.report.add(KeyEntries, p.entry.Sys.ID, p.action, i.env.Entries.Publish(ctx, p.entry))
+	}
+
+	return nil
+}
+
+func (i *importer) webhooks(ctx context.Context) error {
+	existing, err := byID(ctx, i.space.Webhooks.List(ctx), func(w *contentful.Webhook) string { return w.Sys.ID })
+	if err != nil {
+		return fmt.Errorf("listing webhooks: %w", err)
+	}
+
+	for _, webhook := range i.snapshot.Webhooks {
+		id := webhook.Sys.ID
+		current := existing[id]
+		next := *webhook
+		next.Sys = &contentful.Sys{ID: id}
+		if current != nil && (i.opts.SkipExisting || equalWithoutSys(current, &next)) {
+			i.report.add(KeyWebhooks, id, ActionSkipped, nil)
+			continue
+		}
+
+		action := ActionCreated
+		if current != nil {
+			next.Sys.Version = current.Sys.Version
+			action = ActionUpdated
+		}
+		i.report.add(KeyWebhooks, id, action, i.space.Webhooks.Upsert(ctx, &next))
+	}
+
+	return nil
+}
+
+func (i *importer) roles(ctx context.Context) error {
+	existing, err := byID(ctx, i.space.Roles.List(ctx), func(r *contentful.Role) string { return r.Sys.ID })
+	if err != nil {
+		return fmt.Errorf("listing roles: %w", err)
+	}
+
+	for _, role := range i.snapshot.Roles {
+		id := role.Sys.ID
+		current := existing[id]
+		next := *role
+		next.Sys = &contentful.Sys{ID: id}
+		if current != nil && (i.opts.SkipExisting || equalWithoutSys(current, &next)) {
+			i.report.add(KeyRoles, id, ActionSkipped, nil)
+			continue
+		}
+
+		action := ActionCreated
+		if current != nil {
+			next.Sys.Version = current.Sys.Version
+			action = ActionUpdated
+		}
+		i.report.add(KeyRoles, id, action, i.space.Roles.Upsert(ctx, &next))
+	}
+
+	return nil
+}
+
+// byID collects the items of all pages of the collection by their id
+func byID[T any](ctx context.Context, col *contentful.Collection[T], id func(*T) string) (map[string]*T, error) {
+	items := map[string]*T{}
+	for item, err := range col.All(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		items[id(&item)] = &item
+	}
+
+	return items, nil
+}
+
+// lookup collects the items with the given ids by their id
+func lookup[T any](ctx context.Context, list func(context.Context) *contentful.Collection[T], ids []string, id func(*T) string) (map[string]*T, error) {
+	items := map[string]*T{}
+	for batch := range slices.Chunk(ids, lookupBatchSize) {
+		col := list(ctx)
+		col.Query.In("sys.id", batch)
+		found, err := byID(ctx, col, id)
+		if err != nil {
+			return nil, err
+		}
+		for key, item := range found {
+			items[key] = item
+		}
+	}
+
+	return items, nil
+}
+
+// dependencyOrder orders the ids so that their dependencies come first.
+// Dependencies which are not in ids are ignored, cycles are broken in the
+// order of ids.
+func dependencyOrder(ids []string, dependencies func(id string) []string) []string {
+	known := map[string]bool{}
+	for _, id := range ids {
+		known[id] = true
+	}
+
+	order := make([]string, 0, len(ids))
+	visited := map[string]bool{}
+	var visit func(id string)
+	visit = func(id string) {
+		if visited[id] || !known[id] {
+			return
+		}
+		visited[id] = true
+		for _, dependency := range dependencies(id) {
+			visit(dependency)
+		}
+		order = append(order, id)
+	}
+	for _, id := range ids {
+		visit(id)
+	}
+
+	return order
+}
+
+// links returns the ids of the entries linked in the value, including
+// entries embedded in rich text
+func links(v interface{}) []string {
+	var ids []string
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if sys, ok := v["sys"].(map[string]interface{}); ok && sys["type"] == "Link" && sys["linkType"] == "Entry" {
+			if id, ok := sys["id"].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+		for _, key := range sortedKeys(v) {
+			ids = append(ids, links(v[key])...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			ids = append(ids, links(item)...)
+		}
+	}
+
+	return ids
+}
+
+func contentTypeOf(entry *contentful.Entry) string {
+	if entry.Sys.ContentType == nil || entry.Sys.ContentType.Sys == nil {
+		return ""
+	}
+
+	return entry.Sys.ContentType.Sys.ID
+}
+
+// published reports whether an exported entity has been published
+func published(sys *contentful.Sys) bool {
+	return sys.PublishedVersion > 0 && sys.ArchivedVersion == 0
+}
+
+// active reports whether the entity is published without pending changes
+func active(sys *contentful.Sys) bool {
+	return sys.PublishedVersion > 0 && sys.Version == sys.PublishedVersion+1
+}
+
+func processedFiles(asset *contentful.Asset) bool {
+	if asset.Fields == nil {
+		return true
+	}
+	for _, file := range asset.Fields.File {
+		if file != nil && file.URL == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameLocale(current, locale *contentful.Locale) bool {
+	return current.Name == locale.Name &&
+		current.FallbackCode == locale.FallbackCode &&
+		current.Optional == locale.Optional &&
+		current.CDA == locale.CDA &&
+		current.CMA == locale.CMA
+}
+
+func sameContentType(current, ct *contentful.ContentType) bool {
+	return current.Name == ct.Name &&
+		current.Description == ct.Description &&
+		current.DisplayField == ct.DisplayField &&
+		equal(current.Fields, ct.Fields)
+}
+
+// sameAsset compares the fields and the files by name and content type, as
+// the urls differ between spaces
+func sameAsset(current, asset *contentful.Asset) bool {
+	if published(asset.Sys) && !active(current.Sys) {
+		return false
+	}
+	if current.Fields == nil || asset.Fields == nil {
+		return current.Fields == asset.Fields
+	}
+	if !equal(current.Fields.Title, asset.Fields.Title) ||
+		!equal(current.Fields.Description, asset.Fields.Description) ||
+		!equal(tagIDs(current.Metadata), tagIDs(asset.Metadata)) ||
+		len(current.Fields.File) != len(asset.Fields.File) {
+		return false
+	}
+	for locale, file := range asset.Fields.File {
+		currentFile := current.Fields.File[locale]
+		if currentFile == nil || currentFile.URL == "" || file == nil ||
+			currentFile.Name != file.Name || currentFile.ContentType != file.ContentType {
+			return false
+		}
+	}
+
+	return true
+}
+
+func sameEntry(current, entry *contentful.Entry) bool {
+	if published(entry.Sys) && !active(current.Sys) {
+		return false
+	}
+
+	return equal(current.Fields, entry.Fields) && equal(tagIDs(current.Metadata), tagIDs(entry.Metadata))
+}
+
+func tagIDs(metadata *contentful.Metadata) []string {
+	ids := []string{}
+	if metadata == nil {
+		return ids
+	}
+	for _, tag := range metadata.Tags {
+		if tag.Sys != nil {
+			ids = append(ids, tag.Sys.ID)
+		}
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+// equalWithoutSys compares the json representation of the entities
+// without their sys
+func equalWithoutSys(a, b any) bool {
+	return equal(withoutSys(a), withoutSys(b))
+}
+
+func withoutSys(v any) map[string]interface{} {
+	var m map[string]interface{}
+	if data, err := json.Marshal(v); err == nil {
+		_ = json.Unmarshal(data, &m)
+	}
+	delete(m, "sys")
+
+	return m
+}
+
+// equal compares the json representation of the values
+func equal(a, b any) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+
+	return errA == nil && errB == nil && bytes.Equal(dataA, dataB)
+}
@@ -0,0 +1,246 @@
+package transfer
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/foomo/contentful/internal/cmafake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importSpace = `{
+	"content_types": [
+		{"sys": {"id": "cat", "version": 2, "publishedVersion": 1}, "name": "Cat", "displayField": "name", "fields": [
+			{"id": "name", "name": "Name", "type": "Symbol", "required": true},
+			{"id": "friend", "name": "Friend", "type": "Link", "linkType": "Entry"}
+		]}
+	],
+	"editor_interfaces": [
+		{"sys": {"id": "default", "version": 3, "contentType": {"sys": {"id": "cat", "type": "Link", "linkType": "ContentType"}}}, "controls": [{"fieldId": "name", "widgetId": "singleLine", "widgetNamespace": "builtin"}]}
+	],
+	"entries": [
+		{"metadata": {"tags": [{"sys": {"type": "Link", "linkType": "Tag", "id": "cute"}}]}, "sys": {"id": "happycat", "version": 3, "publishedVersion": 2, "contentType": {"sys": {"id": "cat"}}}, "fields": {"name": {"en-US": "Happy Cat"}, "friend": {"en-US": {"sys": {"type": "Link", "linkType": "Entry", "id": "grumpycat"}}}}},
+		{"sys": {"id": "grumpycat", "version": 2, "publishedVersion": 1, "contentType": {"sys": {"id": "cat"}}}, "fields": {"name": {"en-US": "Grumpy Cat"}}},
+		{"sys": {"id": "draftcat", "version": 1, "contentType": {"sys": {"id": "cat"}}}, "fields": {"name": {"en-US": "Draft Cat"}}}
+	],
+	"assets": [
+		{"sys": {"id": "nyancat", "version": 2, "publishedVersion": 1}, "fields": {"title": {"en-US": "Nyan Cat"}, "file": {"en-US": {"fileName": "nyancat.png", "contentType": "image/png", "url": "//images.example/space/nyancat/token/nyancat.png"}}}}
+	],
+	"locales": [
+		{"sys": {"id": "en"}, "name": "English", "code": "en-US", "default": true, "contentDeliveryApi": true, "contentManagementApi": true},
+		{"sys": {"id": "ch"}, "name": "Swiss German", "code": "de-CH", "fallbackCode": "de-DE", "contentDeliveryApi": true, "contentManagementApi": true},
+		{"sys": {"id": "de"}, "name": "German", "code": "de-DE", "fallbackCode": "en-US", "contentDeliveryApi": true, "contentManagementApi": true}
+	],
+	"tags": [
+		{"sys": {"id": "cute", "type": "Tag", "visibility": "public"}, "name": "Cute"}
+	],
+	"webhook_definitions": [
+		{"sys": {"id": "hook"}, "name": "Hook", "url": "https://example.com", "topics": ["*.*"]}
+	],
+	"roles": [
+		{"sys": {"id": "editor", "version": 1}, "name": "Editor", "policies": [{"effect": "allow", "actions": "all"}], "permissions": {"ContentModel": ["read"]}}
+	]
+}`
+
+const importTarget = `{
+	"locales": [
+		{"sys": {"id": "en", "version": 1, "createdAt": "2024-06-01T00:00:00Z"}, "name": "English", "code": "en-US", "default": true, "contentDeliveryApi": true, "contentManagementApi": true}
+	]
+}`
+
+// importSnapshot exports the space with drafts
+func importSnapshot(t *testing.T, data string, opts *ExportOptions) *Snapshot {
+	t.Helper()
+	_, source := cmafake.New(t, data)
+
+	var buf bytes.Buffer
+	require.NoError(t, Export(t.Context(), source, "master", &buf, opts))
+	snapshot, err := ReadSnapshot(&buf)
+	require.NoError(t, err)
+
+	return snapshot
+}
+
+func TestImport(t *testing.T) {
+	snapshot := importSnapshot(t, importSpace, &ExportOptions{IncludeDrafts: true})
+	f, target := cmafake.New(t, importTarget)
+
+	report, err := Import(t.Context(), target, "master", snapshot, &ImportOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, `locales: 2 created, 0 updated, 1 skipped, 0 failed
+tags: 1 created, 0 updated, 0 skipped, 0 failed
+contentTypes: 1 created, 0 updated, 0 skipped, 0 failed
+editorInterfaces: 0 created, 1 updated, 0 skipped, 0 failed
+assets: 1 created, 0 updated, 0 skipped, 0 failed
+entries: 3 created, 0 updated, 0 skipped, 0 failed
+webhooks: 1 created, 0 updated, 0 skipped, 0 failed
+roles: 1 created, 0 updated, 0 skipped, 0 failed
+`, report.String())
+
+	assert.Equal(t, []string{
+		"POST /environments/master/locales",
+		"POST /environments/master/locales",
+		"PUT /environments/master/tags/cute",
+		"PUT /environments/master/content_types/cat",
+		"PUT /environments/master/content_types/cat/published",
+		"PUT /environments/master/content_types/cat/editor_interface",
+		"PUT /environments/master/assets/nyancat",
+		"PUT /environments/master/assets/nyancat/files/en-US/process",
+		"PUT /environments/master/assets/nyancat/published",
+		"PUT /environments/master/entries/grumpycat",
+		"PUT /environments/master/entries/happycat",
+		"PUT /environments/master/entries/draftcat",
+		"PUT /environments/master/entries/grumpycat/published",
+		"PUT /environments/master/entries/happycat/published",
+		"PUT /webhook_definitions/hook",
+		"PUT /roles/editor",
+	}, writes(f.Requests))
+
+	assert.Equal(t, []string{"en-US", "de-DE", "de-CH"}, codes(f.Items["locales"]), "fallback locales are created first")
+	assert.Equal(t, "public", cmafake.Sys(f.Find("tags", "cute"))["visibility"])
+	assert.Equal(t, "//assets.example/nyancat/nyancat.png", f.Find("assets", "nyancat")["fields"].(map[string]interface{})["file"].(map[string]interface{})["en-US"].(map[string]interface{})["url"])
+	assert.Equal(t, map[string]interface{}{"tags": []interface{}{map[string]interface{}{"sys": map[string]interface{}{"type": "Link", "linkType": "Tag", "id": "cute"}}}}, f.Find("entries", "happycat")["metadata"])
+	assert.NotNil(t, cmafake.Sys(f.Find("entries", "happycat"))["publishedVersion"])
+	assert.Nil(t, cmafake.Sys(f.Find("entries", "draftcat"))["publishedVersion"])
+
+	// importing again changes nothing
+	f.Requests = nil
+	report, err = Import(t.Context(), target, "master", snapshot, &ImportOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Empty(t, writes(f.Requests))
+	for _, result := range report.Results {
+		assert.Equal(t, ActionSkipped, result.Action, result.Type+" "+result.ID)
+	}
+}
+
+func TestImportResume(t *testing.T) {
+	snapshot := importSnapshot(t, importSpace, &ExportOptions{IncludeDrafts: true})
+	f, target := cmafake.New(t, importTarget)
+	f.Fail["grumpycat"] = true
+
+	report, err := Import(t.Context(), target, "master", snapshot, &ImportOptions{PollInterval: time.Millisecond})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `entries "grumpycat"`)
+	assert.Contains(t, err.Error(), `entries "happycat"`)
+	assert.Equal(t, []string{"grumpycat", "happycat"}, resultIDs(report.Failed()), "the link to the failed entry is not resolvable")
+
+	f.Requests = nil
+	report, err = Import(t.Context(), target, "master", snapshot, &ImportOptions{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"PUT /environments/master/entries/grumpycat",
+		"PUT /environments/master/entries/happycat",
+		"PUT /environments/master/entries/grumpycat/published",
+		"PUT /environments/master/entries/happycat/published",
+	}, writes(f.Requests))
+	assert.Contains(t, report.String(), "entries: 1 created, 1 updated, 1 skipped, 0 failed")
+}
+
+func TestImportExisting(t *testing.T) {
+	snapshot := importSnapshot(t, importSpace, nil)
+	existing := `{"entries": [
+		{"sys": {"id": "grumpycat", "version": 5, "publishedVersion": 4, "contentType": {"sys": {"id": "cat"}}}, "fields": {"name": {"en-US": "Grumpy"}}}
+	]}`
+	opts := &ImportOptions{SkipLocales: true, SkipTags: true, SkipContentModel: true, SkipWebhooks: true, SkipRoles: true}
+
+	t.Run("update", func(t *testing.T) {
+		f, target := cmafake.New(t, existing)
+		_, err := Import(t.Context(), target, "master", &Snapshot{Entries: snapshot.Entries}, opts)
+		require.NoError(t, err)
+
+		grumpycat := f.Find("entries", "grumpycat")
+		assert.Equal(t, map[string]interface{}{"name": map[string]interface{}{"en-US": "Grumpy Cat"}}, grumpycat["fields"])
+		assert.InDelta(t, float64(7), cmafake.Version(grumpycat), 0, "updated with version 5 and published")
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		f, target := cmafake.New(t, existing)
+		opts.SkipExisting = true
+		report, err := Import(t.Context(), target, "master", &Snapshot{Entries: snapshot.Entries}, opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]interface{}{"name": map[string]interface{}{"en-US": "Grumpy"}}, f.Find("entries", "grumpycat")["fields"])
+		assert.Equal(t, "entries: 1 created, 0 updated, 1 skipped, 0 failed\n", report.String())
+	})
+}
+
+func TestImportAssetDir(t *testing.T) {
+	files := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("png:" + r.URL.Path))
+	}))
+	defer files.Close()
+
+	dir := t.TempDir()
+	snapshot := importSnapshot(t, strings.ReplaceAll(exportSpace, "{{files}}", files.Listener.Addr().String()), &ExportOptions{
+		AssetDir:   dir,
+		HTTPClient: files.Client(),
+	})
+	f, target := cmafake.New(t, `{}`)
+
+	_, err := Import(t.Context(), target, "master", &Snapshot{Assets: snapshot.Assets}, &ImportOptions{
+		AssetDir:     dir,
+		PollInterval: time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"upload-0": "png:/space/nyancat/token/nyancat.png"}, f.Uploads)
+	assert.Contains(t, f.Requests, "POST /environments/master/uploads")
+	assert.Equal(t, map[string]interface{}{"size": float64(36)}, f.Find("assets", "nyancat")["fields"].(map[string]interface{})["file"].(map[string]interface{})["en-US"].(map[string]interface{})["details"])
+}
+
+func TestDependencyOrder(t *testing.T) {
+	deps := map[string][]string{"a": {"b", "x"}, "b": {"c"}, "c": {"a"}, "d": nil}
+	assert.Equal(t, []string{"c", "b", "a", "d"}, dependencyOrder([]string{"a", "b", "c", "d"}, func(id string) []string {
+		return deps[id]
+	}))
+}
+
+// writes returns the requests changing the space
+func writes(requests []string) []string {
+	return slices.DeleteFunc(slices.Clone(requests), func(request string) bool {
+		return strings.HasPrefix(request, "GET ")
+	})
+}
+
+func codes(items []map[string]interface{}) []string {
+	var codes []string
+	for _, item := range items {
+		codes = append(codes, item["code"].(string))
+	}
+
+	return codes
+}
+
+func resultIDs(results []*Result) []string {
+	var ids []string
+	for _, result := range results {
+		ids = append(ids, result.ID)
+	}
+
+	return ids
+}
+
+func TestImportAssetOutsideDir(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "export")
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret"), []byte("secret"), 0o600))
+
+	snapshot := importSnapshot(t, strings.ReplaceAll(importSpace, "//images.example/space/nyancat/token/nyancat.png", "//images.example/../../secret"), nil)
+	f, target := cmafake.New(t, `{}`)
+
+	report, err := Import(t.Context(), target, "master", &Snapshot{Assets: snapshot.Assets}, &ImportOptions{
+		AssetDir:     dir,
+		PollInterval: time.Millisecond,
+	})
+	require.ErrorContains(t, err, "leads outside of the asset dir")
+	assert.Equal(t, []string{"nyancat"}, resultIDs(report.Failed()))
+	assert.Empty(t, f.Uploads)
+}
@@ -0,0 +1,31 @@
+package transfer
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/foomo/contentful"
+)
+
+// Snapshot is the content of an export file
+type Snapshot struct {
+	ContentTypes     []*contentful.ContentType     `json:"contentTypes,omitempty"`
+	Tags             []*contentful.Tag             `json:"tags,omitempty"`
+	EditorInterfaces []*contentful.EditorInterface `json:"editorInterfaces,omitempty"`
+	Entries          []*contentful.Entry           `json:"entries,omitempty"`
+	Assets           []*contentful.Asset           `json:"assets,omitempty"`
+	Locales          []*contentful.Locale          `json:"locales,omitempty"`
+	Webhooks         []*contentful.Webhook         `json:"webhooks,omitempty"`
+	Roles            []*contentful.Role            `json:"roles,omitempty"`
+}
+
+// ReadSnapshot reads an export file written by Export or the
+// contentful-export tool
+func ReadSnapshot(r io.Reader) (*Snapshot, error) {
+	var snapshot Snapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
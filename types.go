@@ -21,4 +21,28 @@ type Sys struct {
 	PublishedBy      *Sys         `json:"publishedBy,omitempty"`
 	PublishedVersion int          `json:"publishedVersion,omitempty"`
 	Locale           string       `json:"locale,omitempty"`
+	Status           *Link        `json:"status,omitempty"`
+	Visibility       string       `json:"visibility,omitempty"`
+}
+
+// Link model
+type Link struct {
+	Sys *Sys `json:"sys"`
+}
+
+// NewLink returns a link to the entity of the given type
+func NewLink(linkType, id string) *Link {
+	return &Link{
+		Sys: &Sys{
+			ID:       id,
+			Type:     "Link",
+			LinkType: linkType,
+		},
+	}
+}
+
+// Location model
+type Location struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
 }
@@ -19,6 +19,7 @@ func (service *UploadService) Uploads(ctx context.Context, spaceID string, file
 	path := fmt.Sprintf("/spaces/%s%s/uploads", spaceID, getEnvPath(service.c))
 	method := http.MethodPost
 
+	ctx = service.c.operation(ctx, "Upload", "Uploads", spaceID, "")
 	req, err := service.c.newRequest(ctx, method, path, nil, file, map[string]string{"Content-Type": "application/octet-stream"})
 	if err != nil {
 		return nil, err
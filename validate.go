@@ -0,0 +1,416 @@
+package contentful
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/foomo/contentful/richtext"
+)
+
+// EntryValidationError lists the problems found by ValidateEntry in the
+// shape of the details of a ValidationFailedError
+type EntryValidationError struct {
+	Errors []*ErrorDetail
+}
+
+func (e *EntryValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		var segments []string
+		if path, ok := err.Path.([]interface{}); ok {
+			for _, segment := range path {
+				segments = append(segments, fmt.Sprint(segment))
+			}
+		}
+		messages = append(messages, strings.Join(segments, ".")+": "+err.Details)
+	}
+
+	return "entry validation failed: " + strings.Join(messages, "; ")
+}
+
+// ValidateEntry validates the fields of an entry with values by locale
+// against the content type before it is sent to the api. It checks for
+// unknown fields and locales, values of non-localized fields in other than
+// the default locale, required fields, field types and the size, range,
+// dateRange, regexp, in, enabledNodeTypes and enabledMarks validations.
+// The linkContentType, assetFileSize and assetImageDimensions validations
+// are checked for links resolved to entries and assets. Unique and mime
+// type group validations can not be checked locally.
+//
+// Without locales the locale codes are not checked and required fields need
+// a value in any locale. The returned error is an *EntryValidationError.
+func ValidateEntry(ct *ContentType, entry *Entry, locales []Locale) error {
+	v := &entryValidator{locales: locales}
+	for _, locale := range locales {
+		if locale.Default {
+			v.defaultLocale = locale.Code
+		}
+	}
+
+	fields := make(map[string]bool, len(ct.Fields))
+	for _, field := range ct.Fields {
+		fields[field.ID] = true
+	}
+	for _, id := range slices.Sorted(maps.Keys(entry.Fields)) {
+		if !fields[id] {
+			v.add("unknown", []interface{}{"fields", id}, nil, fmt.Sprintf("The property %q is not expected", id))
+		}
+	}
+
+	for _, field := range ct.Fields {
+		v.field(field, entry.Fields[field.ID])
+	}
+
+	if len(v.errors) == 0 {
+		return nil
+	}
+
+	return &EntryValidationError{Errors: v.errors}
+}
+
+type entryValidator struct {
+	locales       []Locale
+	defaultLocale string
+	errors        []*ErrorDetail
+}
+
+func (v *entryValidator) add(name string, path []interface{}, value interface{}, details string) {
+	v.errors = append(v.errors, &ErrorDetail{
+		Name:    name,
+		Path:    path,
+		Details: details,
+		Value:   value,
+	})
+}
+
+func (v *entryValidator) field(field *Field, raw interface{}) {
+	values := map[string]interface{}{}
+	if raw != nil {
+		var ok bool
+		if values, ok = normalizeValue(raw).(map[string]interface{}); !ok {
+			v.add("type", []interface{}{"fields", field.ID}, raw, "The value must be an object of values by locale")
+			return
+		}
+	}
+
+	for _, locale := range slices.Sorted(maps.Keys(values)) {
+		path := []interface{}{"fields", field.ID, locale}
+		switch {
+		case len(v.locales) > 0 && !slices.ContainsFunc(v.locales, func(l Locale) bool { return l.Code == locale }):
+			v.add("unknown", path, nil, fmt.Sprintf("The locale %q is not expected", locale))
+		case !field.Localized && v.defaultLocale != "" && locale != v.defaultLocale:
+			v.add("unknown", path, nil, fmt.Sprintf("The property %q is not localized, only the default locale %q is expected", field.ID, v.defaultLocale))
+		case !isEmptyValue(values[locale]):
+			v.value(field.Type, field.LinkType, field.Items, field.Validations, values[locale], path, locale)
+		}
+	}
+
+	if field.Required {
+		v.required(field, values)
+	}
+}
+
+// required checks the default locale of non-localized fields and all
+// locales which are not optional of localized fields
+func (v *entryValidator) required(field *Field, values map[string]interface{}) {
+	details := fmt.Sprintf("The property %q is required here", field.ID)
+	if len(v.locales) == 0 {
+		for _, value := range values {
+			if !isEmptyValue(value) {
+				return
+			}
+		}
+		v.add("required", []interface{}{"fields", field.ID}, nil, details)
+		return
+	}
+
+	for _, locale := range v.locales {
+		if field.Localized && locale.Optional && !locale.Default {
+			continue
+		}
+		if !field.Localized && !locale.Default {
+			continue
+		}
+		if isEmptyValue(values[locale.Code]) {
+			v.add("required", []interface{}{"fields", field.ID, locale.Code}, nil, details)
+		}
+	}
+}
+
+func (v *entryValidator) value(fieldType, linkType string, items *FieldTypeArrayItem, validations []FieldValidation, value interface{}, path []interface{}, locale string) {
+	if !hasFieldType(fieldType, linkType, value) {
+		expected := fieldType
+		if linkType != "" {
+			expected += " of " + linkType
+		}
+		v.add("type", path, value, "The type of the value is incorrect, expected type: "+expected)
+		return
+	}
+
+	if fieldType == FieldTypeArray && items != nil {
+		for i, item := range value.([]interface{}) {
+			v.value(items.Type, items.LinkType, nil, items.Validations, item, append(slices.Clip(path), i), locale)
+		}
+	}
+
+	for _, validation := range validations {
+		v.validation(validation, value, path, locale)
+	}
+}
+
+func (v *entryValidator) validation(validation FieldValidation, value interface{}, path []interface{}, locale string) {
+	// validations are usually parsed as values but can be pointers too
+	if rv := reflect.ValueOf(validation); rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		validation = rv.Elem().Interface()
+	}
+
+	switch validation := validation.(type) {
+	case FieldValidationSize:
+		var size int
+		switch value := value.(type) {
+		case string:
+			size = utf8.RuneCountInString(value)
+		case []interface{}:
+			size = len(value)
+		default:
+			return
+		}
+		if !inMinMax(float64(size), validation.Size) {
+			v.add("size", path, value, message(validation.ErrorMessage, minMaxDetails("Size", validation.Size)))
+		}
+	case FieldValidationRange:
+		if number, ok := value.(float64); ok && !inMinMax(number, validation.Range) {
+			v.add("range", path, value, message(validation.ErrorMessage, minMaxDetails("Value", validation.Range)))
+		}
+	case FieldValidationDate:
+		s, _ := value.(string)
+		date, err := parseDate(s)
+		if err != nil || validation.Range == nil {
+			return
+		}
+		if (!validation.Range.Min.IsZero() && date.Before(validation.Range.Min)) ||
+			(!validation.Range.Max.IsZero() && date.After(validation.Range.Max)) {
+			v.add("dateRange", path, value, message(validation.ErrorMessage, "Date must be within the range"))
+		}
+	case FieldValidationRegex:
+		s, ok := value.(string)
+		if !ok || validation.Regex == nil {
+			return
+		}
+		re, err := compileRegex(validation.Regex)
+		if err != nil {
+			// patterns which are not supported by regexp can not be checked
+			return
+		}
+		if !re.MatchString(s) {
+			v.add("regexp", path, value, message(validation.ErrorMessage, "Does not match given regex"))
+		}
+	case FieldValidationPredefinedValues:
+		in, _ := normalizeValue(validation.In).([]interface{})
+		if !slices.ContainsFunc(in, func(expected interface{}) bool { return reflect.DeepEqual(expected, value) }) {
+			v.add("in", path, value, message(validation.ErrorMessage, "Value must be one of expected values"))
+		}
+	case FieldValidationLink:
+		if sys := resolvedSys(value, "Entry"); sys != nil {
+			contentType, _ := sysValue(sys, "contentType")["sys"].(map[string]interface{})
+			if id, _ := contentType["id"].(string); !slices.Contains(validation.LinkContentType, id) {
+				v.add("linkContentType", path, value, "Link to entry has invalid content type")
+			}
+		}
+	case FieldValidationFileSize:
+		if file := resolvedAssetFile(value, locale); file != nil {
+			size, _ := sysValue(file, "details")["size"].(float64)
+			if !inMinMax(size, validation.Size) {
+				v.add("assetFileSize", path, value, message(validation.ErrorMessage, minMaxDetails("File size", validation.Size)))
+			}
+		}
+	case FieldValidationDimension:
+		if file := resolvedAssetFile(value, locale); file != nil {
+			image := sysValue(sysValue(file, "details"), "image")
+			width, _ := image["width"].(float64)
+			height, _ := image["height"].(float64)
+			if !inMinMax(width, validation.Width) || !inMinMax(height, validation.Height) {
+				v.add("assetImageDimensions", path, value, message(validation.ErrorMessage, "Image dimensions are not within the range"))
+			}
+		}
+	case FieldValidationEnabledNodeTypes:
+		v.richText(value, path, &richtext.Constraints{NodeTypes: enabledNodeTypes(validation.EnabledNodeTypes)}, "enabledNodeTypes", validation.ErrorMessage)
+	case FieldValidationEnabledMarks:
+		v.richText(value, path, &richtext.Constraints{Marks: enabledMarks(validation.EnabledMarks)}, "enabledMarks", validation.ErrorMessage)
+	}
+}
+
+func (v *entryValidator) richText(value interface{}, path []interface{}, constraints *richtext.Constraints, name, errorMessage string) {
+	doc, err := richtext.FromValue(value)
+	if err != nil {
+		return
+	}
+
+	var constraintErr *richtext.ConstraintError
+	if err := constraints.Validate(doc); errors.As(err, &constraintErr) {
+		v.add(name, path, nil, message(errorMessage, constraintErr.Error()))
+	}
+}
+
+// hasFieldType reports whether the normalized value is of the field type
+func hasFieldType(fieldType, linkType string, value interface{}) bool {
+	switch fieldType {
+	case FieldTypeSymbol, FieldTypeText:
+		_, ok := value.(string)
+		return ok
+	case FieldTypeInteger:
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case FieldTypeNumber:
+		_, ok := value.(float64)
+		return ok
+	case FieldTypeBoolean:
+		_, ok := value.(bool)
+		return ok
+	case FieldTypeDate:
+		s, ok := value.(string)
+		if !ok {
+			return false
+		}
+		_, err := parseDate(s)
+		return err == nil
+	case FieldTypeLocation:
+		location, ok := value.(map[string]interface{})
+		_, lat := location["lat"].(float64)
+		_, lon := location["lon"].(float64)
+		return ok && lat && lon
+	case FieldTypeObject:
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return true
+		}
+		return false
+	case FieldTypeRichText:
+		doc, ok := value.(map[string]interface{})
+		return ok && doc["nodeType"] == string(richtext.NodeTypeDocument)
+	case FieldTypeLink:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if link, ok := linkOf(object); ok {
+			return linkType == "" || link.Sys.LinkType == linkType
+		}
+		return resolvedSys(value, linkType) != nil
+	case FieldTypeArray:
+		_, ok := value.([]interface{})
+		return ok
+	default:
+		return true
+	}
+}
+
+// normalizeValue converts a value into its JSON representation, e.g. a
+// *Link into a map and numbers into float64
+func normalizeValue(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+
+	return normalized
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case []interface{}:
+		return len(value) == 0
+	default:
+		return false
+	}
+}
+
+func inMinMax(value float64, minMax *MinMax) bool {
+	if minMax == nil {
+		return true
+	}
+
+	return (!minMax.HasMin() || value >= minMax.Min) && (!minMax.HasMax() || value <= minMax.Max)
+}
+
+func minMaxDetails(subject string, minMax *MinMax) string {
+	switch {
+	case minMax.HasMin() && minMax.HasMax():
+		return fmt.Sprintf("%s must be between %v and %v", subject, minMax.Min, minMax.Max)
+	case minMax.HasMin():
+		return fmt.Sprintf("%s must be at least %v", subject, minMax.Min)
+	default:
+		return fmt.Sprintf("%s must be at most %v", subject, minMax.Max)
+	}
+}
+
+// message returns the custom error message of a validation or the default
+func message(errorMessage, details string) string {
+	if errorMessage != "" {
+		return errorMessage
+	}
+
+	return details
+}
+
+// compileRegex compiles a javascript pattern with its i, m and s flags
+func compileRegex(regex *Regex) (*regexp.Regexp, error) {
+	var flags string
+	for _, flag := range regex.Flags {
+		if strings.ContainsRune("ims", flag) {
+			flags += string(flag)
+		}
+	}
+	if flags != "" {
+		flags = "(?" + flags + ")"
+	}
+
+	return regexp.Compile(flags + regex.Pattern)
+}
+
+// resolvedSys returns the sys of a link resolved to an entry or asset
+func resolvedSys(value interface{}, sysType string) map[string]interface{} {
+	object, _ := value.(map[string]interface{})
+	sys := sysValue(object, "sys")
+	if sys == nil || sys["type"] != sysType {
+		return nil
+	}
+
+	return sys
+}
+
+// resolvedAssetFile returns the file of the locale of a link resolved to an asset
+func resolvedAssetFile(value interface{}, locale string) map[string]interface{} {
+	if resolvedSys(value, "Asset") == nil {
+		return nil
+	}
+	file := sysValue(sysValue(value.(map[string]interface{}), "fields"), "file")
+	if localized := sysValue(file, locale); localized != nil {
+		return localized
+	}
+
+	return file
+}
+
+// sysValue returns the object of the key, nil if there is none
+func sysValue(object map[string]interface{}, key string) map[string]interface{} {
+	value, _ := object[key].(map[string]interface{})
+	return value
+}
@@ -0,0 +1,190 @@
+package contentful
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validateContentTypeJSON = `{
+	"sys": {"id": "cat", "type": "ContentType"},
+	"fields": [
+		{"id": "name", "type": "Symbol", "required": true, "localized": true,
+			"validations": [{"size": {"min": 2, "max": 10}}, {"regexp": {"pattern": "^[a-z]+$", "flags": "i"}}]},
+		{"id": "lives", "type": "Integer", "validations": [{"range": {"min": 1, "max": 9}, "message": "Cats have up to 9 lives"}]},
+		{"id": "color", "type": "Symbol", "validations": [{"in": ["black", "white"]}]},
+		{"id": "birthday", "type": "Date", "validations": [{"dateRange": {"min": "2000-01-01T00:00:00"}}]},
+		{"id": "friends", "type": "Array", "items": {"type": "Link", "linkType": "Entry", "validations": [{"linkContentType": ["cat"]}]},
+			"validations": [{"size": {"max": 2}}]},
+		{"id": "photo", "type": "Link", "linkType": "Asset", "validations": [{"assetFileSize": {"max": 1000}}]},
+		{"id": "bio", "type": "RichText", "validations": [{"enabledNodeTypes": ["heading-2"]}, {"enabledMarks": ["bold"]}]},
+		{"id": "home", "type": "Location"}
+	]
+}`
+
+var validateLocales = []Locale{
+	{Code: "en-US", Default: true},
+	{Code: "de-DE"},
+	{Code: "fr-FR", Optional: true},
+}
+
+func validateContentType(t *testing.T) *ContentType {
+	t.Helper()
+	var ct *ContentType
+	require.NoError(t, json.Unmarshal([]byte(validateContentTypeJSON), &ct))
+
+	return ct
+}
+
+func TestValidateEntry(t *testing.T) {
+	entry := &Entry{
+		Sys: &Sys{ID: "nyan"},
+		Fields: map[string]interface{}{
+			"name":     map[string]interface{}{"en-US": "Nyan", "de-DE": "Nyan"},
+			"lives":    map[string]interface{}{"en-US": 9},
+			"color":    map[string]interface{}{"en-US": "black"},
+			"birthday": map[string]interface{}{"en-US": "2011-04-02"},
+			"friends": map[string]interface{}{"en-US": []interface{}{
+				NewLink("Entry", "happy"),
+			}},
+			"photo": map[string]interface{}{"en-US": map[string]interface{}{
+				"sys": map[string]interface{}{"id": "photo", "type": "Link", "linkType": "Asset"},
+			}},
+			"bio": map[string]interface{}{"en-US": map[string]interface{}{
+				"nodeType": "document", "data": map[string]interface{}{}, "content": []interface{}{},
+			}},
+			"home": map[string]interface{}{"en-US": map[string]interface{}{"lat": 52.5, "lon": 13.4}},
+		},
+	}
+
+	assert.NoError(t, ValidateEntry(validateContentType(t), entry, validateLocales))
+}
+
+func TestValidateEntryErrors(t *testing.T) {
+	entry := &Entry{
+		Sys: &Sys{ID: "nyan"},
+		Fields: map[string]interface{}{
+			"name":     map[string]interface{}{"en-US": "Nyan the cat!", "it-IT": "Nyan"},
+			"lives":    map[string]interface{}{"en-US": 10, "de-DE": 9},
+			"color":    map[string]interface{}{"en-US": "rainbow"},
+			"birthday": map[string]interface{}{"en-US": "1999-12-31"},
+			"friends": map[string]interface{}{"en-US": []interface{}{
+				map[string]interface{}{"sys": map[string]interface{}{"id": "dog", "type": "Entry", "contentType": map[string]interface{}{
+					"sys": map[string]interface{}{"id": "dog", "type": "Link", "linkType": "ContentType"},
+				}}},
+				map[string]interface{}{"sys": map[string]interface{}{"id": "photo", "type": "Link", "linkType": "Asset"}},
+				map[string]interface{}{"sys": map[string]interface{}{"id": "happy", "type": "Link", "linkType": "Entry"}},
+			}},
+			"photo": map[string]interface{}{"en-US": map[string]interface{}{
+				"sys":    map[string]interface{}{"id": "photo", "type": "Asset"},
+				"fields": map[string]interface{}{"file": map[string]interface{}{"en-US": map[string]interface{}{"details": map[string]interface{}{"size": 2000}}}},
+			}},
+			"bio": map[string]interface{}{"en-US": map[string]interface{}{
+				"nodeType": "document", "data": map[string]interface{}{}, "content": []interface{}{
+					map[string]interface{}{"nodeType": "heading-1", "data": map[string]interface{}{}, "content": []interface{}{
+						map[string]interface{}{"nodeType": "text", "value": "Nyan", "marks": []interface{}{map[string]interface{}{"type": "italic"}}, "data": map[string]interface{}{}},
+					}},
+				},
+			}},
+			"home":  map[string]interface{}{"en-US": "Berlin"},
+			"tail":  map[string]interface{}{"en-US": true},
+			"extra": "not by locale",
+		},
+	}
+
+	err := ValidateEntry(validateContentType(t), entry, validateLocales)
+	var validationErr *EntryValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	type result struct {
+		Name string
+		Path []interface{}
+	}
+	results := make([]result, 0, len(validationErr.Errors))
+	for _, detail := range validationErr.Errors {
+		results = append(results, result{detail.Name, detail.Path.([]interface{})})
+	}
+	assert.Equal(t, []result{
+		{"unknown", []interface{}{"fields", "extra"}},
+		{"unknown", []interface{}{"fields", "tail"}},
+		{"size", []interface{}{"fields", "name", "en-US"}},
+		{"regexp", []interface{}{"fields", "name", "en-US"}},
+		{"unknown", []interface{}{"fields", "name", "it-IT"}},
+		{"required", []interface{}{"fields", "name", "de-DE"}},
+		{"unknown", []interface{}{"fields", "lives", "de-DE"}},
+		{"range", []interface{}{"fields", "lives", "en-US"}},
+		{"in", []interface{}{"fields", "color", "en-US"}},
+		{"dateRange", []interface{}{"fields", "birthday", "en-US"}},
+		{"linkContentType", []interface{}{"fields", "friends", "en-US", 0}},
+		{"type", []interface{}{"fields", "friends", "en-US", 1}},
+		{"size", []interface{}{"fields", "friends", "en-US"}},
+		{"assetFileSize", []interface{}{"fields", "photo", "en-US"}},
+		{"enabledNodeTypes", []interface{}{"fields", "bio", "en-US"}},
+		{"enabledMarks", []interface{}{"fields", "bio", "en-US"}},
+		{"type", []interface{}{"fields", "home", "en-US"}},
+	}, results)
+
+	assert.Equal(t, "Cats have up to 9 lives", validationErr.Errors[7].Details)
+	assert.Equal(t, "Size must be between 2 and 10", validationErr.Errors[2].Details)
+	assert.Contains(t, err.Error(), "fields.friends.en-US.1: The type of the value is incorrect, expected type: Link of Entry")
+}
+
+func TestValidateEntryRequiredWithoutLocales(t *testing.T) {
+	ct := validateContentType(t)
+
+	err := ValidateEntry(ct, &Entry{Fields: map[string]interface{}{"name": map[string]interface{}{"de-DE": ""}}}, nil)
+	var validationErr *EntryValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Errors, 1)
+	assert.Equal(t, "required", validationErr.Errors[0].Name)
+	assert.Equal(t, []interface{}{"fields", "name"}, validationErr.Errors[0].Path)
+
+	assert.NoError(t, ValidateEntry(ct, &Entry{Fields: map[string]interface{}{"name": map[string]interface{}{"de-DE": "Nyan"}}}, nil))
+}
+
+func TestValidateEntryZeroBounds(t *testing.T) {
+	var ct *ContentType
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"sys": {"id": "counter", "type": "ContentType"},
+		"fields": [
+			{"id": "count", "type": "Integer", "validations": [{"range": {"min": 0}}]},
+			{"id": "tags", "type": "Array", "items": {"type": "Symbol"}, "validations": [{"size": {"max": 0}}]}
+		]
+	}`), &ct))
+
+	err := ValidateEntry(ct, &Entry{Fields: map[string]interface{}{
+		"count": map[string]interface{}{"en-US": -1},
+		"tags":  map[string]interface{}{"en-US": []interface{}{"cute"}},
+	}}, validateLocales)
+	var validationErr *EntryValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Errors, 2)
+	assert.Equal(t, "Value must be at least 0", validationErr.Errors[0].Details)
+	assert.Equal(t, "Size must be at most 0", validationErr.Errors[1].Details)
+
+	assert.NoError(t, ValidateEntry(ct, &Entry{Fields: map[string]interface{}{
+		"count": map[string]interface{}{"en-US": 0},
+		"tags":  map[string]interface{}{"en-US": []interface{}{}},
+	}}, validateLocales))
+}
+
+func TestValidateEntryDateWithOffset(t *testing.T) {
+	ct := validateContentType(t)
+	entry := func(birthday string) *Entry {
+		return &Entry{Fields: map[string]interface{}{
+			"name":     map[string]interface{}{"en-US": "Nyan", "de-DE": "Nyan"},
+			"birthday": map[string]interface{}{"en-US": birthday},
+		}}
+	}
+
+	// the web app stores dates with minutes and the time zone
+	assert.NoError(t, ValidateEntry(ct, entry("2021-03-04T00:00+01:00"), validateLocales))
+
+	err := ValidateEntry(ct, entry("1999-12-31T23:00+01:00"), validateLocales)
+	var validationErr *EntryValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Errors, 1)
+	assert.Equal(t, "dateRange", validationErr.Errors[0].Name)
+}
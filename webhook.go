@@ -40,9 +40,10 @@ func (webhook *Webhook) GetVersion() int {
 
 // List returns webhooks collection
 func (service *WebhooksService) List(ctx context.Context, spaceID string) *Collection[Webhook] {
-	path := fmt.Sprintf("/spaces/%s%s/webhook_definitions", spaceID, getEnvPath(service.c))
+	path := fmt.Sprintf("/spaces/%s/webhook_definitions", spaceID)
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Webhooks", "List", spaceID, "")
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return &Collection[Webhook]{}
@@ -57,9 +58,10 @@ func (service *WebhooksService) List(ctx context.Context, spaceID string) *Colle
 
 // Get returns a single webhook entity
 func (service *WebhooksService) Get(ctx context.Context, spaceID, webhookID string) (*Webhook, error) {
-	path := fmt.Sprintf("/spaces/%s%s/webhook_definitions/%s", spaceID, getEnvPath(service.c), webhookID)
+	path := fmt.Sprintf("/spaces/%s/webhook_definitions/%s", spaceID, webhookID)
 	method := http.MethodGet
 
+	ctx = service.c.operation(ctx, "Webhooks", "Get", spaceID, webhookID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return nil, err
@@ -73,7 +75,8 @@ func (service *WebhooksService) Get(ctx context.Context, spaceID, webhookID stri
 	return webhook, nil
 }
 
-// Upsert updates or creates a new entity
+// Upsert updates or creates a new entity. Webhooks with an id are put with
+// it, so they are created with the given id; webhooks without are posted.
 func (service *WebhooksService) Upsert(ctx context.Context, spaceID string, webhook *Webhook) error {
 	bytesArray, err := Marshal(webhook)
 	if err != nil {
@@ -83,14 +86,15 @@ func (service *WebhooksService) Upsert(ctx context.Context, spaceID string, webh
 	var path string
 	var method string
 
-	if webhook.Sys != nil && webhook.Sys.CreatedAt != "" {
-		path = fmt.Sprintf("/spaces/%s%s/webhook_definitions/%s", spaceID, getEnvPath(service.c), webhook.Sys.ID)
+	if webhook.Sys != nil && webhook.Sys.ID != "" {
+		path = fmt.Sprintf("/spaces/%s/webhook_definitions/%s", spaceID, webhook.Sys.ID)
 		method = http.MethodPut
 	} else {
-		path = fmt.Sprintf("/spaces/%s%s/webhook_definitions", spaceID, getEnvPath(service.c))
+		path = fmt.Sprintf("/spaces/%s/webhook_definitions", spaceID)
 		method = http.MethodPost
 	}
 
+	ctx = service.c.operation(ctx, "Webhooks", "Upsert", spaceID, sysID(webhook.Sys))
 	req, err := service.c.newRequest(ctx, method, path, nil, bytes.NewReader(bytesArray), nil)
 	if err != nil {
 		return err
@@ -103,9 +107,10 @@ func (service *WebhooksService) Upsert(ctx context.Context, spaceID string, webh
 
 // Delete the webhook
 func (service *WebhooksService) Delete(ctx context.Context, spaceID string, webhook *Webhook) error {
-	path := fmt.Sprintf("/spaces/%s%s/webhook_definitions/%s", spaceID, getEnvPath(service.c), webhook.Sys.ID)
+	path := fmt.Sprintf("/spaces/%s/webhook_definitions/%s", spaceID, webhook.Sys.ID)
 	method := http.MethodDelete
 
+	ctx = service.c.operation(ctx, "Webhooks", "Delete", spaceID, webhook.Sys.ID)
 	req, err := service.c.newRequest(ctx, method, path, nil, nil, nil)
 	if err != nil {
 		return err
@@ -187,3 +187,50 @@ func TestWebhookDelete(t *testing.T) {
 	err = cma.Webhooks.Delete(context.TODO(), spaceID, webhook)
 	require.NoError(t, err)
 }
+
+func TestWebhookPathsWithEnvironment(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		_, _ = fmt.Fprintln(w, readTestData(t, "webhook.json"))
+	}))
+	defer server.Close()
+
+	// webhooks belong to the space, the environment is not part of the path
+	c, err := New(APICMA, CMAToken, WithBaseURL(server.URL), WithEnvironment("staging"), WithRetryPolicy(NoRetryPolicy()))
+	require.NoError(t, err)
+
+	_, err = c.Webhooks.List(t.Context(), spaceID).Next()
+	require.NoError(t, err)
+	webhook, err := c.Webhooks.Get(t.Context(), spaceID, "7fstd9fZ9T2p3kwD49FxhI")
+	require.NoError(t, err)
+	require.NoError(t, c.Webhooks.Delete(t.Context(), spaceID, webhook))
+
+	assert.Equal(t, []string{
+		"GET /spaces/" + spaceID + "/webhook_definitions",
+		"GET /spaces/" + spaceID + "/webhook_definitions/7fstd9fZ9T2p3kwD49FxhI",
+		"DELETE /spaces/" + spaceID + "/webhook_definitions/" + webhook.Sys.ID,
+	}, requests)
+}
+
+func TestWebhookUpsertWithID(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintln(w, readTestData(t, "webhook.json"))
+	}))
+	defer server.Close()
+
+	cma = NewCMA(CMAToken)
+	cma.BaseURL = server.URL
+
+	// a new webhook with an id is created with the id
+	require.NoError(t, cma.Webhooks.Upsert(t.Context(), spaceID, &Webhook{Sys: &Sys{ID: "hook"}, Name: "Hook", URL: "https://example.com"}))
+	require.NoError(t, cma.Webhooks.Upsert(t.Context(), spaceID, &Webhook{Name: "Hook", URL: "https://example.com"}))
+
+	assert.Equal(t, []string{
+		"PUT /spaces/" + spaceID + "/webhook_definitions/hook",
+		"POST /spaces/" + spaceID + "/webhook_definitions",
+	}, requests)
+}